@@ -0,0 +1,59 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "sync"
+
+// SchemaCache keeps the models of the parsed schema files, which could be
+// shared by the parsers of multiple input files, so that the schemas in
+// <import> or <include> statements are only parsed once. The schemas are
+// cached for the options of the parser which they depend on, except for the
+// hooks, file systems and fetchers, so the parsers sharing a cache should use
+// the same ones. It is safe for concurrent use.
+type SchemaCache struct {
+	mu      sync.RWMutex
+	schemas map[schemaCacheKey]*Schema
+}
+
+// schemaCacheKey identifies a parsed schema file in the cache. The proto
+// trees and the generated code depend on the options of the parser, which
+// are encoded in the options string, and whether the types are resolved from
+// the referenced schemas.
+type schemaCacheKey struct {
+	file    string
+	options string
+	extract bool
+}
+
+// NewSchemaCache creates an empty schema cache.
+func NewSchemaCache() *SchemaCache {
+	return &SchemaCache{schemas: make(map[schemaCacheKey]*Schema)}
+}
+
+// load returns the model of the parsed schema file. It returns false if the
+// cache is nil or the schema file hasn't been parsed.
+func (c *SchemaCache) load(key schemaCacheKey) (*Schema, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	schema, ok := c.schemas[key]
+	return schema, ok
+}
+
+// store adds the model of the parsed schema file to the cache.
+func (c *SchemaCache) store(key schemaCacheKey, schema *Schema) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.schemas[key] = schema
+}
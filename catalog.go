@@ -0,0 +1,171 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// catalog holds the entries of the OASIS XML catalogs, which map the
+// namespace URIs and the schema locations to the local files. Only the uri,
+// rewriteURI, system, rewriteSystem and nextCatalog entries are supported.
+type catalog struct {
+	entries []catalogEntry
+}
+
+// catalogEntry is an entry of the catalog. The match is the name of the uri
+// entry, the systemId of the system entry, or the start string of the rewrite
+// entries. The value is the uri of the uri or system entry, or the rewrite
+// prefix of the rewrite entries, which is resolved relative to the directory
+// of the catalog file.
+type catalogEntry struct {
+	kind  string
+	match string
+	value string
+	dir   string
+}
+
+// loadCatalogs reads the catalog files specified in the options. The catalog
+// files are read from the FS of the options, or from the operating system
+// file system if the FS is nil.
+func (opt *Options) loadCatalogs() (err error) {
+	if opt.catalog != nil || len(opt.Catalogs) == 0 {
+		return
+	}
+	c := &catalog{}
+	for _, name := range opt.Catalogs {
+		if err = opt.loadCatalog(c, name, map[string]bool{}); err != nil {
+			return
+		}
+	}
+	opt.catalog = c
+	return
+}
+
+// loadCatalog reads the entries of the catalog file with the given name, and
+// the catalogs referenced by its nextCatalog entries, into c.
+func (opt *Options) loadCatalog(c *catalog, name string, loaded map[string]bool) (err error) {
+	if loaded[name] {
+		return
+	}
+	loaded[name] = true
+	var f io.ReadCloser
+	if f, err = opt.openFile(name); err != nil {
+		return fmt.Errorf("xgen: load catalog %s: %w", name, err)
+	}
+	defer f.Close()
+	var next []string
+	dir := opt.dirPath(name)
+	decoder := xml.NewDecoder(f)
+	decoder.CharsetReader = charset.NewReaderLabel
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("xgen: load catalog %s: %w", name, err)
+		}
+		ele, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		attrs := map[string]string{}
+		for _, attr := range ele.Attr {
+			attrs[attr.Name.Local] = attr.Value
+		}
+		switch ele.Name.Local {
+		case "uri":
+			c.entries = append(c.entries, catalogEntry{kind: "uri", match: attrs["name"], value: attrs["uri"], dir: dir})
+		case "system":
+			c.entries = append(c.entries, catalogEntry{kind: "system", match: attrs["systemId"], value: attrs["uri"], dir: dir})
+		case "rewriteURI":
+			c.entries = append(c.entries, catalogEntry{kind: "rewriteURI", match: attrs["uriStartString"], value: attrs["rewritePrefix"], dir: dir})
+		case "rewriteSystem":
+			c.entries = append(c.entries, catalogEntry{kind: "rewriteSystem", match: attrs["systemIdStartString"], value: attrs["rewritePrefix"], dir: dir})
+		case "nextCatalog":
+			next = append(next, opt.catalogPath(dir, attrs["catalog"]))
+		}
+	}
+	for _, name := range next {
+		if err = opt.loadCatalog(c, name, loaded); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// resolve returns the mapped location of the given identifier by the exact
+// match entry of the given kind, or by the rewrite entry of the given kind
+// with the longest matching start string.
+func (c *catalog) resolve(opt *Options, kind, rewriteKind, id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	for _, entry := range c.entries {
+		if entry.kind == kind && entry.match == id {
+			return opt.catalogPath(entry.dir, entry.value), true
+		}
+	}
+	var rewrite *catalogEntry
+	for i, entry := range c.entries {
+		if entry.kind == rewriteKind && entry.match != "" && strings.HasPrefix(id, entry.match) &&
+			(rewrite == nil || len(entry.match) > len(rewrite.match)) {
+			rewrite = &c.entries[i]
+		}
+	}
+	if rewrite != nil {
+		return opt.catalogPath(rewrite.dir, rewrite.value+strings.TrimPrefix(id, rewrite.match)), true
+	}
+	return "", false
+}
+
+// catalogPath returns the path of the location in the catalog entry, which is
+// relative to the directory of the catalog file unless it's an absolute path
+// or URL.
+func (opt *Options) catalogPath(dir, location string) string {
+	if isValidURL(location) || path.IsAbs(location) || filepath.IsAbs(location) {
+		return location
+	}
+	return opt.joinPath(dir, location)
+}
+
+// locateSchema returns the path or URL of the schema imported from the given
+// namespace or included at the given location. The catalogs of the options
+// are consulted before the location is resolved relative to the current
+// schema, the schema location is looked up in the system and uri entries, and
+// then the namespace in the uri entries. The second return value reports
+// whether the schema is mapped by the catalogs.
+func (opt *Options) locateSchema(namespace, location string) (string, bool) {
+	if opt.catalog != nil {
+		ids := []string{location}
+		if resolved := opt.resolveLocation(location); location != "" && isValidURL(resolved) && resolved != location {
+			ids = append(ids, resolved)
+		}
+		for _, id := range ids {
+			if file, ok := opt.catalog.resolve(opt, "system", "rewriteSystem", id); ok {
+				return file, true
+			}
+			if file, ok := opt.catalog.resolve(opt, "uri", "rewriteURI", id); ok {
+				return file, true
+			}
+		}
+		if file, ok := opt.catalog.resolve(opt, "uri", "rewriteURI", namespace); ok {
+			return file, true
+		}
+	}
+	return opt.resolveLocation(location), false
+}
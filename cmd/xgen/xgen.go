@@ -12,6 +12,16 @@
 //        -o <path> Output file path or directory for the generated code
 //        -p        Specify the package name
 //        -l        Specify the language of generated code (Go/C/Java/Rust/TypeScript)
+//        -validate Generate Validate methods for the Go language
+//        -xsdtime  Use the date and time types of the xsd package for the Go language
+//        -optional <policy> Go types of the optional values: pointer, complex or generic
+//        -fetch    Fetch the remote schemas over HTTP(S)
+//        -cache <path> Cache directory for the remote schemas
+//        -offline  Use the cached remote schemas only
+//        -catalog <path> XML catalog files separated by comma
+//        -j <N>    Number of files to parse in parallel
+//        -ns <namespace=package> Namespace to package mappings separated by comma
+//        -import <path> Go import path of the output directory
 //        -h        Output this help and exit
 //        -v        Output version and exit
 //
@@ -20,6 +30,9 @@
 //
 // The default package name and output directory are "schema" and "xgen_out".
 //
+// The schemas referenced by an http(s) URL are skipped unless the -fetch flag
+// is specified, or the -offline flag to use the cached ones only.
+//
 // Currently support language is Go.
 
 package main
@@ -28,6 +41,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/xuri/xgen"
 )
@@ -35,11 +51,21 @@ import (
 // Config holds user-defined overrides and filters that are used when
 // generating source code from an XSD document.
 type Config struct {
-	I       string
-	O       string
-	Pkg     string
-	Lang    string
-	Version string
+	I        string
+	O        string
+	Pkg      string
+	Lang     string
+	Validate bool
+	XSDTime  bool
+	Optional string
+	Fetch    bool
+	Cache    string
+	Offline  bool
+	Catalogs []string
+	Jobs     int
+	Packages map[string]string
+	Import   string
+	Version  string
 }
 
 // Cfg are the default config for xgen. The default package name and output
@@ -64,11 +90,21 @@ func parseFlags() *Config {
 	oPtr := flag.String("o", "xgen_out", "Output file path or directory for the generated code")
 	pkgPtr := flag.String("p", "", "Specify the package name")
 	langPtr := flag.String("l", "", "Specify the language of generated code")
+	validatePtr := flag.Bool("validate", false, "Generate Validate methods for the Go language")
+	xsdTimePtr := flag.Bool("xsdtime", false, "Use the date and time types of the xsd package for the Go language")
+	optionalPtr := flag.String("optional", xgen.OptionalPointer, "Go types of the optional values: pointer, complex or generic")
+	fetchPtr := flag.Bool("fetch", false, "Fetch the remote schemas over HTTP(S)")
+	cachePtr := flag.String("cache", "", "Cache directory for the remote schemas")
+	offlinePtr := flag.Bool("offline", false, "Use the cached remote schemas only")
+	catalogPtr := flag.String("catalog", "", "XML catalog files separated by comma")
+	jobsPtr := flag.Int("j", 1, "Number of files to parse in parallel")
+	nsPtr := flag.String("ns", "", "Namespace to package mappings separated by comma")
+	importPtr := flag.String("import", "", "Go import path of the output directory")
 	verPtr := flag.Bool("v", false, "Show version and exit")
 	helpPtr := flag.Bool("h", false, "Show this help and exit")
 	flag.Parse()
 	if *helpPtr {
-		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2025 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n", Cfg.Version)
+		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2025 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -validate\tGenerate Validate methods for the Go language\r\n  -xsdtime\tUse the date and time types of the xsd package for the Go language\r\n  -optional <policy>\tGo types of the optional values: pointer, complex or generic\r\n  -fetch  \tFetch the remote schemas over HTTP(S)\r\n  -cache <path>\tCache directory for the remote schemas\r\n  -offline\tUse the cached remote schemas only\r\n  -catalog <path>\tXML catalog files separated by comma\r\n  -j <N>  \tNumber of files to parse in parallel\r\n  -ns <namespace=package>\tNamespace to package mappings separated by comma\r\n  -import <path>\tGo import path of the output directory\r\n  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n", Cfg.Version)
 		os.Exit(0)
 	}
 	if *verPtr {
@@ -95,6 +131,44 @@ func parseFlags() *Config {
 	if *pkgPtr != "" {
 		Cfg.Pkg = *pkgPtr
 	}
+	Cfg.Validate = *validatePtr
+	Cfg.XSDTime = *xsdTimePtr
+	switch Cfg.Optional = *optionalPtr; Cfg.Optional {
+	case xgen.OptionalPointer, xgen.OptionalComplex, xgen.OptionalGeneric:
+	default:
+		fmt.Println("unsupport optionality policy", Cfg.Optional)
+		os.Exit(1)
+	}
+	Cfg.Fetch = *fetchPtr
+	Cfg.Cache = *cachePtr
+	Cfg.Offline = *offlinePtr
+	if *catalogPtr != "" {
+		Cfg.Catalogs = strings.Split(*catalogPtr, ",")
+	}
+	if *nsPtr != "" {
+		Cfg.Packages = make(map[string]string)
+		for _, mapping := range strings.Split(*nsPtr, ",") {
+			i := strings.LastIndex(mapping, "=")
+			if i <= 0 || i == len(mapping)-1 {
+				fmt.Println("invalid namespace to package mapping", mapping)
+				os.Exit(1)
+			}
+			Cfg.Packages[mapping[:i]] = mapping[i+1:]
+		}
+	}
+	Cfg.Import = *importPtr
+	if Cfg.Jobs = *jobsPtr; Cfg.Jobs < 1 {
+		fmt.Println("the number of files to parse in parallel must be at least 1")
+		os.Exit(1)
+	}
+	if Cfg.Offline && Cfg.Cache == "" {
+		fmt.Println("must specify the cache directory in offline mode")
+		os.Exit(1)
+	}
+	if Cfg.Cache != "" && !Cfg.Fetch && !Cfg.Offline {
+		fmt.Println("must specify the fetch or offline mode to use the cache directory")
+		os.Exit(1)
+	}
 	return &Cfg
 }
 
@@ -105,24 +179,81 @@ func main() {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	for _, file := range files {
-		if err = xgen.NewParser(&xgen.Options{
-			FilePath:            file,
-			InputDir:            cfg.I,
-			OutputDir:           cfg.O,
-			Lang:                cfg.Lang,
-			Package:             cfg.Pkg,
-			IncludeMap:          make(map[string]bool),
-			LocalNameNSMap:      make(map[string]string),
-			NSSchemaLocationMap: make(map[string]string),
-			ParseFileList:       make(map[string]bool),
-			ParseFileMap:        make(map[string][]interface{}),
-			ProtoTree:           make([]interface{}, 0),
-			RemoteSchema:        make(map[string][]byte),
-		}).Parse(); err != nil {
-			fmt.Printf("process error on %s: %s\r\n", file, err.Error())
-			os.Exit(1)
+	if errs := generate(cfg, files); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Printf("%s\r\n", err.Error())
 		}
+		os.Exit(1)
 	}
 	fmt.Println("done")
 }
+
+// generate parses the XML schema files with a pool of workers, which share
+// the cache of the parsed schemas. The generated code of each file is kept in
+// memory, and written in the order of the files after all of them have been
+// parsed, so that the output doesn't depend on the number of workers. The
+// errors of all files are returned in the same order. The remote schemas are
+// fetched only in the fetch or offline mode, and skipped otherwise.
+func generate(cfg *Config, files []string) (errs []error) {
+	cache := xgen.NewSchemaCache()
+	var fetcher xgen.Fetcher
+	if cfg.Fetch || cfg.Offline {
+		fetcher = &xgen.HTTPFetcher{CacheDir: cfg.Cache, Offline: cfg.Offline}
+	}
+	outputs := make([]*xgen.MemoryOutput, len(files))
+	fileErrs := make([]error, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				outputs[i] = xgen.NewMemoryOutput()
+				fileErrs[i] = xgen.NewParser(&xgen.Options{
+					FilePath:          files[i],
+					InputDir:          cfg.I,
+					OutputDir:         cfg.O,
+					Lang:              cfg.Lang,
+					Package:           cfg.Pkg,
+					Validation:        cfg.Validate,
+					XSDTimeTypes:      cfg.XSDTime,
+					Optionality:       cfg.Optional,
+					Output:            outputs[i],
+					Fetcher:           fetcher,
+					Catalogs:          cfg.Catalogs,
+					Cache:             cache,
+					NamespacePackages: cfg.Packages,
+					ImportPath:        cfg.Import,
+				}).Parse()
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for i, file := range files {
+		if fileErrs[i] != nil {
+			errs = append(errs, fmt.Errorf("process error on %s: %w", file, fileErrs[i]))
+			continue
+		}
+		for _, name := range outputs[i].Names() {
+			content, _ := outputs[i].File(name)
+			if err := writeFile(name, content); err != nil {
+				errs = append(errs, fmt.Errorf("process error on %s: %w", file, err))
+			}
+		}
+	}
+	return
+}
+
+// writeFile writes the generated code to the file, the parent directories are
+// created if they don't exist.
+func writeFile(name string, content []byte) error {
+	if err := xgen.PrepareOutputDir(filepath.Dir(name)); err != nil {
+		return err
+	}
+	return os.WriteFile(name, content, 0o644)
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+// resolveDerivations completes the complex types in the proto tree after
+// parsing. The types derived by restriction inherit the attributes of the
+// base type unless they are redeclared or prohibited, and the types with
+// simple content take the value type of the base type resolved through the
+// chain of the base types, unless they embed the base complex type. The
+// prohibited attributes are removed from all complex types.
+func (opt *Options) resolveDerivations() {
+	resolved := make(map[*ComplexType]bool)
+	for _, ele := range opt.ProtoTree {
+		if v, ok := ele.(*ComplexType); ok {
+			opt.Schema.resolveDerivation(v, resolved)
+		}
+	}
+}
+
+// resolveDerivation completes the complex type declared in the schema, the
+// base types declared in the same schema are completed first. The base types
+// declared in the referenced schemas have been completed by their parsers.
+func (s *Schema) resolveDerivation(v *ComplexType, resolved map[*ComplexType]bool) {
+	if resolved[v] {
+		return
+	}
+	resolved[v] = true
+	base := s.ComplexType(v.BaseName)
+	if base != nil && s.ComplexTypes[s.name(base.Name)] == base {
+		s.resolveDerivation(base, resolved)
+	}
+	if v.Derivation == "restriction" && base != nil {
+		attributes, attributeGroups := s.attributeUses(base, map[*ComplexType]bool{v: true})
+		v.Attributes = mergeAttributes(attributes, v.Attributes)
+		v.AttributeGroup = mergeAttributeGroups(attributeGroups, v.AttributeGroup)
+	}
+	// the types with simple content derived by extension from a complex
+	// type embed the base type, and inherit its value
+	if v.SimpleContent && v.BaseName.Local != "" && (base == nil || v.Derivation == "restriction") {
+		if valueType := s.valueType(v, map[*ComplexType]bool{}); valueType != "" {
+			v.Base = valueType
+		}
+	}
+	attributes := v.Attributes[:0]
+	for _, attribute := range v.Attributes {
+		if !attribute.Prohibited {
+			attributes = append(attributes, attribute)
+		}
+	}
+	v.Attributes = attributes
+}
+
+// attributeUses returns the attributes and attribute group references of the
+// complex type, including those inherited from the base types by extension.
+// The attributes of the types derived by restriction have been completed.
+func (s *Schema) attributeUses(v *ComplexType, visited map[*ComplexType]bool) (attributes []Attribute, attributeGroups []AttributeGroup) {
+	if visited[v] {
+		return
+	}
+	visited[v] = true
+	if v.Derivation == "extension" {
+		if base := s.ComplexType(v.BaseName); base != nil {
+			attributes, attributeGroups = s.attributeUses(base, visited)
+		}
+	}
+	attributes = mergeAttributes(attributes, v.Attributes)
+	attributeGroups = mergeAttributeGroups(attributeGroups, v.AttributeGroup)
+	return
+}
+
+// valueType returns the value type of the complex type with simple content,
+// which is the value type of the base complex type, or the base simple type
+// resolved to the built-in type. The value type of a base complex type
+// derived from a built-in type is its resolved Base. It returns an empty
+// string if the base type can't be found.
+func (s *Schema) valueType(v *ComplexType, visited map[*ComplexType]bool) string {
+	if visited[v] {
+		return ""
+	}
+	visited[v] = true
+	if base := s.ComplexType(v.BaseName); base != nil {
+		if base.SimpleContent && base.BaseName.Local != "" {
+			if valueType := s.valueType(base, visited); valueType != "" {
+				return valueType
+			}
+		}
+		return base.Base
+	}
+	if base := s.SimpleType(v.BaseName); base != nil && !base.List && !base.Union {
+		return base.Base
+	}
+	return ""
+}
+
+// mergeAttributes returns the inherited attributes, with the attributes
+// redeclared by the derived type in place, followed by the other attributes
+// declared by the derived type.
+func mergeAttributes(inherited, declared []Attribute) []Attribute {
+	attributes := make([]Attribute, 0, len(inherited)+len(declared))
+	redeclared := make(map[string]bool)
+	for _, attribute := range inherited {
+		for _, declaredAttribute := range declared {
+			if !attribute.Wildcard && declaredAttribute.Name == attribute.Name {
+				attribute = declaredAttribute
+				redeclared[attribute.Name] = true
+				break
+			}
+		}
+		attributes = append(attributes, attribute)
+	}
+	for _, attribute := range declared {
+		if attribute.Wildcard || !redeclared[attribute.Name] {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// mergeAttributeGroups returns the inherited attribute group references,
+// followed by the other references declared by the derived type.
+func mergeAttributeGroups(inherited, declared []AttributeGroup) []AttributeGroup {
+	attributeGroups := append([]AttributeGroup{}, inherited...)
+	for _, attrGroup := range declared {
+		var found bool
+		for _, inheritedGroup := range inherited {
+			if inheritedGroup.Ref == attrGroup.Ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			attributeGroups = append(attributeGroups, attrGroup)
+		}
+	}
+	return attributeGroups
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "fmt"
+
+// OutputError records an error and the path of the output directory or the
+// generated source code file which caused it.
+type OutputError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the error message of the output error.
+func (e *OutputError) Error() string {
+	return fmt.Sprintf("xgen: write output %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error of the output error.
+func (e *OutputError) Unwrap() error {
+	return e.Err
+}
+
+// ResolveError records an error of resolving the type referenced in a schema
+// from the included or imported schema at the location. The Type is empty for
+// the errors of linking the included or imported schema itself.
+type ResolveError struct {
+	Type     string
+	Location string
+	Err      error
+}
+
+// Error returns the error message of the resolve error.
+func (e *ResolveError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("xgen: resolve schema %s: %v", e.Location, e.Err)
+	}
+	return fmt.Sprintf("xgen: resolve type %s from %s: %v", e.Type, e.Location, e.Err)
+}
+
+// Unwrap returns the underlying error of the resolve error.
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaError records an error of parsing the XML schema document with the
+// position in the file and the XSD construct being processed when the error
+// occurred. The construct is empty for the syntax errors of the document.
+type SchemaError struct {
+	File      string
+	Line      int
+	Column    int
+	Construct string
+	Err       error
+}
+
+// Error returns the error message of the schema error.
+func (e *SchemaError) Error() string {
+	if e.Construct == "" {
+		return fmt.Sprintf("xgen: %s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("xgen: %s:%d:%d: %s: %v", e.File, e.Line, e.Column, e.Construct, e.Err)
+}
+
+// Unwrap returns the underlying error of the schema error.
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// ErrOffline is returned by the HTTPFetcher in offline mode, when the remote
+// schema isn't in the cache directory.
+var ErrOffline = errors.New("xgen: remote schema is not cached in offline mode")
+
+// Fetcher fetches the remote schemas referenced by http(s) URL in <import> or
+// <include> statements. The remote schemas are skipped if no fetcher is
+// specified in the options.
+type Fetcher interface {
+	Fetch(url string) ([]byte, error)
+}
+
+// HTTPFetcher fetches the remote schemas with the HTTP client, the default
+// HTTP client is used if the client is nil. If the cache directory is
+// specified, the fetched schemas are stored in it and reused by the later
+// fetches. In offline mode, the schemas are only read from the cache
+// directory.
+type HTTPFetcher struct {
+	Client   *http.Client
+	CacheDir string
+	Offline  bool
+}
+
+// Fetch returns the content of the remote schema with the given URL.
+func (f *HTTPFetcher) Fetch(url string) ([]byte, error) {
+	var cacheFile string
+	if f.CacheDir != "" {
+		sum := sha256.Sum256([]byte(url))
+		cacheFile = filepath.Join(f.CacheDir, hex.EncodeToString(sum[:])+path.Ext(url))
+		if body, err := os.ReadFile(cacheFile); err == nil {
+			return body, nil
+		}
+	}
+	if f.Offline {
+		return nil, ErrOffline
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("xgen: fetch %s: unexpected status %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if cacheFile != "" {
+		if err = PrepareOutputDir(f.CacheDir); err != nil {
+			return nil, err
+		}
+		if err = writeCacheFile(cacheFile, body); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// writeCacheFile writes the fetched schema to a temporary file in the cache
+// directory and renames it to the cache file, so that the concurrent fetches
+// never read a partially written cache file.
+func writeCacheFile(name string, body []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(body); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
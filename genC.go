@@ -10,7 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"os"
 	"reflect"
 	"strings"
 )
@@ -37,22 +36,18 @@ var cBuildInType = map[string]bool{
 // GenC generates C programming language source code for XML schema definition
 // files.
 func (gen *CodeGenerator) GenC() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
 		funcName := fmt.Sprintf("C%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
-	}
-	f, err := os.Create(gen.FileWithExtension(".h"))
-	if err != nil {
-		return err
+		if err := callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)}); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 	source := []byte(fmt.Sprintf("%s\n%s", copyright, gen.Field))
-	f.Write(source)
-	return err
+	return gen.writeFile(".h", source)
 }
 
 func innerArray(dataType string) (string, bool) {
@@ -62,7 +57,7 @@ func innerArray(dataType string) (string, bool) {
 	return dataType, false
 }
 
-func genCFieldName(name string, unique bool) (fieldName string) {
+func genCFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -72,12 +67,6 @@ func genCFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
@@ -96,16 +85,38 @@ func genCFieldType(name string) string {
 	return "void"
 }
 
+// genCStruct returns the beginning of the struct declaration of the named
+// complex type or group. The structs of the recursive types are tagged by the
+// type name, so the fields can point to them before the typedef is complete.
+func (gen *CodeGenerator) genCStruct(name string) string {
+	for _, ref := range gen.references(name) {
+		if gen.recursiveType(name, ref) {
+			return fmt.Sprintf("struct %s {\n", genCFieldType(name))
+		}
+	}
+	return "struct {\n"
+}
+
+// genCRecursiveField returns the type and name of the field of the named type
+// in the owner type. The fields whose type references the owner type point to
+// the tagged struct of the type, as a struct can't contain itself.
+func (gen *CodeGenerator) genCRecursiveField(owner, name, fieldType, fieldName string) (string, string) {
+	if gen.recursiveType(owner, name) {
+		return "struct " + fieldType, "*" + fieldName
+	}
+	return fieldType, fieldName
+}
+
 // CSimpleType generates code for simple type XML schema in C language
 // syntax.
 func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			content := fmt.Sprintf("%s %s[];\n", genCFieldType(fieldType), genCFieldName(v.Name, false))
+			fieldType := genCFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			content := fmt.Sprintf("%s %s[];\n", genCFieldType(fieldType), genCFieldName(v.Name))
 			gen.StructAST[v.Name] = content
-			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genCFieldName(v.Name))
+			gen.Field += fmt.Sprintf("%stypedef %s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -117,31 +128,31 @@ func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
 				var plural, fieldType string
 				var ok bool
 				if fieldType, ok = innerArray(genCFieldType(memberType)); ok {
 					plural = "[]"
 				}
-				content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(memberName, false), plural)
+				content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(memberName), plural)
 			}
 			content += "}"
 			gen.StructAST[v.Name] = content
-			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+			fieldName := gen.uniqueName(genCFieldName(v.Name))
+			gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), gen.StructAST[v.Name], fieldName)
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))); ok {
+		if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(v.Base)))); ok {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%stypedef %s;\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), gen.StructAST[v.Name])
 	}
 }
 
@@ -149,23 +160,27 @@ func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 // syntax.
 func (gen *CodeGenerator) CComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := "struct {\n"
+		content := gen.genCStruct(v.Name)
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t%s %s;\n", genCFieldType(fieldType), genCFieldName(attrGroup.Name, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t%s %s;\n", genCFieldType(fieldType), genCFieldName(attrGroup.Name))
 		}
 
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += genCWildcardField(true)
+				continue
+			}
 			var optional string
 			if attribute.Optional {
 				optional = `, optional`
 			}
 			var plural, fieldType string
 			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(attribute.Type)))); ok {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
+			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name), plural, optional)
 		}
 
 		for _, group := range v.Groups {
@@ -173,36 +188,49 @@ func (gen *CodeGenerator) CComplexType(v *ComplexType) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
+			fieldType, fieldName := gen.genCRecursiveField(v.Name, trimNSPrefix(group.Ref), genCFieldType(gen.baseType(trimNSPrefix(group.Ref))), genCFieldName(group.Name))
+			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, fieldName, plural)
 		}
 
 		for _, element := range v.Elements {
+			if element.Wildcard {
+				content += genCWildcardField(false)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
 			var plural, fieldType string
 			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))); ok || element.Plural {
+			if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(element.Type)))); ok || element.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(element.Name, false), plural)
+			fieldType, fieldName := gen.genCRecursiveField(v.Name, gen.baseType(trimNSPrefix(element.Type)), fieldType, genCFieldName(element.Name))
+			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, fieldName, plural)
 		}
 		// TODO: Implement handling of v.Base for the cases of the type being a built-in one and
 		// the case of inheritance/embedding
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genTypeComment(fieldName, v.Doc, v.Assertions, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
 
 // CGroup generates code for group XML schema in C language syntax.
 func (gen *CodeGenerator) CGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := "struct {\n"
+		content := gen.genCStruct(v.Name)
 		for _, element := range v.Elements {
+			if element.Wildcard {
+				content += genCWildcardField(false)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
 			var plural string
 			if element.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)), genCFieldName(element.Name, false), plural)
+			fieldType, fieldName := gen.genCRecursiveField(v.Name, gen.baseType(trimNSPrefix(element.Type)), genCFieldType(gen.baseType(trimNSPrefix(element.Type))), genCFieldName(element.Name))
+			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, fieldName, plural)
 		}
 
 		for _, group := range v.Groups {
@@ -210,12 +238,13 @@ func (gen *CodeGenerator) CGroup(v *Group) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
+			fieldType, fieldName := gen.genCRecursiveField(v.Name, trimNSPrefix(group.Ref), genCFieldType(gen.baseType(trimNSPrefix(group.Ref))), genCFieldName(group.Name))
+			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, fieldName, plural)
 		}
 
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
@@ -226,19 +255,23 @@ func (gen *CodeGenerator) CAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := "struct {\n"
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += genCWildcardField(true)
+				continue
+			}
 			var optional, plural, fieldType string
 			var ok bool
 			if attribute.Optional {
 				optional = `, optional`
 			}
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(attribute.Type)))); ok {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
+			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name), plural, optional)
 		}
 		content += "}"
 		gen.StructAST[v.Name] = content
-		fieldName := genCFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
 	}
 }
@@ -248,10 +281,10 @@ func (gen *CodeGenerator) CElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(v.Type)))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
 		gen.Field += fmt.Sprintf("\ntypedef %s;\n", gen.StructAST[v.Name])
 	}
 }
@@ -261,11 +294,20 @@ func (gen *CodeGenerator) CAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(genCFieldType(gen.baseType(trimNSPrefix(v.Type)))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		fieldName := genCFieldName(v.Name, true)
+		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name), plural)
+		fieldName := gen.uniqueName(genCFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
 	}
 }
+
+// genCWildcardField returns the field of the element or attribute wildcard,
+// the matched elements or attributes are kept as raw strings.
+func genCWildcardField(attribute bool) string {
+	if attribute {
+		return "\tchar *anyAttr[]; // anyAttribute\n"
+	}
+	return "\tchar *any[]; // any\n"
+}
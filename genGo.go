@@ -9,11 +9,15 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
 	"go/format"
-	"os"
+	"math"
+	"path"
 	"reflect"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // CodeGenerator holds code generator overrides and runtime data that are used
@@ -23,45 +27,128 @@ type CodeGenerator struct {
 	File              string
 	Field             string
 	Package           string
-	ImportTime        bool // For Go language
-	ImportEncodingXML bool // For Go language
+	ImportTime        bool   // For Go language
+	ImportEncodingXML bool   // For Go language
+	ImportFmt         bool   // For Go language
+	ImportRegexp      bool   // For Go language
+	ImportXSD         bool   // For Go language
+	Validation        bool   // For Go language
+	Optionality       string // For Go language
+	Output            Output
 	ProtoTree         []interface{}
+	Schema            *Schema
 	StructAST         map[string]string
 	Hook              Hook
+	NamespacePackages map[string]string
+	ImportPath        string // For Go language
+
+	fieldNameCount map[string]int
+	symbols        symbolTable
+	typeReferences map[string][]string
+	imports        map[string]string
+	outputDir      string
+}
+
+// uniqueName returns the given type name with a numeric suffix if the name has
+// been generated before by the code generator.
+func (gen *CodeGenerator) uniqueName(name string) string {
+	if gen.fieldNameCount == nil {
+		gen.fieldNameCount = make(map[string]int)
+	}
+	gen.fieldNameCount[name]++
+	if count := gen.fieldNameCount[name]; count != 1 {
+		return fmt.Sprintf("%s%d", name, count)
+	}
+	return name
 }
 
 var goBuildinType = map[string]bool{
-	"xml.Name":      true,
-	"byte":          true,
-	"[]byte":        true,
-	"bool":          true,
-	"[]bool":        true,
-	"complex64":     true,
-	"complex128":    true,
-	"float32":       true,
-	"float64":       true,
-	"int":           true,
-	"int8":          true,
-	"int16":         true,
-	"int32":         true,
-	"int64":         true,
-	"interface":     true,
-	"[]interface{}": true,
-	"string":        true,
-	"[]string":      true,
-	"time.Time":     true,
-	"uint":          true,
-	"uint8":         true,
-	"uint16":        true,
-	"uint32":        true,
-	"uint64":        true,
+	"xml.Name":       true,
+	"byte":           true,
+	"[]byte":         true,
+	"bool":           true,
+	"[]bool":         true,
+	"complex64":      true,
+	"complex128":     true,
+	"float32":        true,
+	"float64":        true,
+	"int":            true,
+	"int8":           true,
+	"int16":          true,
+	"int32":          true,
+	"int64":          true,
+	"interface":      true,
+	"[]interface{}":  true,
+	"string":         true,
+	"[]string":       true,
+	"time.Time":      true,
+	"uint":           true,
+	"uint8":          true,
+	"uint16":         true,
+	"uint32":         true,
+	"uint64":         true,
+	"xsd.Date":       true,
+	"xsd.DateTime":   true,
+	"xsd.Duration":   true,
+	"xsd.GDay":       true,
+	"xsd.GMonth":     true,
+	"xsd.GMonthDay":  true,
+	"xsd.GYear":      true,
+	"xsd.GYearMonth": true,
+	"xsd.Time":       true,
+}
+
+// The optionality policies of the Go code specify the field types of the
+// optional elements and attributes of the simple types. The complex types are
+// always pointers, and the optional fields are always tagged with omitempty.
+const (
+	// OptionalPointer generates the pointers to the values, which is the
+	// default policy.
+	OptionalPointer = "pointer"
+	// OptionalComplex generates the values, so only the complex types are
+	// pointers, and the zero values are absent.
+	OptionalComplex = "complex"
+	// OptionalGeneric generates the values of the xsd.Optional type.
+	OptionalGeneric = "generic"
+)
+
+// goPresence is how the presence of the value of a field is represented.
+type goPresence int
+
+const (
+	// goRequired is the value which is always present, or a pointer to a
+	// complex type which handles the nil value by itself.
+	goRequired goPresence = iota
+	// goPointer is the pointer to the value, which is nil if absent.
+	goPointer
+	// goZero is the value, which is the zero value if absent.
+	goZero
+	// goOptional is the xsd.Optional value, which is not valid if absent.
+	goOptional
+)
+
+// genGoOptionalType returns the Go field type of the optional element or
+// attribute of the given type by the optionality policy, and how the presence
+// of its value is represented.
+func (gen *CodeGenerator) genGoOptionalType(fieldType string) (string, goPresence) {
+	if strings.HasPrefix(fieldType, "*") || strings.HasPrefix(fieldType, "[]") || fieldType == "interface{}" {
+		return fieldType, goRequired
+	}
+	switch gen.Optionality {
+	case OptionalComplex:
+		return fieldType, goZero
+	case OptionalGeneric:
+		gen.ImportXSD = true
+		return "xsd.Optional[" + fieldType + "]", goOptional
+	}
+	return "*" + fieldType, goPointer
 }
 
 // GenGo generate Go programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenGo() error {
 	err := error(nil)
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
@@ -82,13 +169,10 @@ func (gen *CodeGenerator) GenGo() error {
 		}
 
 		funcName := fmt.Sprintf("Go%s", protoName)
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
-	}
-	f, err := os.Create(gen.FileWithExtension(".go"))
-	if err != nil {
-		return err
+		if err = callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)}); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 	var importPackage, packages string
 	if gen.ImportTime {
 		packages += "\t\"time\"\n"
@@ -96,37 +180,52 @@ func (gen *CodeGenerator) GenGo() error {
 	if gen.ImportEncodingXML {
 		packages += "\t\"encoding/xml\"\n"
 	}
+	if gen.ImportFmt {
+		packages += "\t\"fmt\"\n"
+	}
+	if gen.ImportRegexp {
+		packages += "\t\"regexp\"\n"
+	}
+	if gen.ImportXSD {
+		packages += "\t\"github.com/xuri/xgen/xsd\"\n"
+	}
+	for _, pkg := range gen.sortedImports() {
+		importPath := fmt.Sprintf("%q", path.Join(gen.ImportPath, pkg))
+		if alias := gen.imports[pkg]; alias != packageIdentifier(path.Base(pkg)) {
+			importPath = alias + " " + importPath
+		}
+		packages += "\t" + importPath + "\n"
+	}
 	if packages != "" {
 		importPackage = fmt.Sprintf("import (\n%s)", packages)
 	}
 	packageName := gen.Package
+	if pkg := gen.currentPackage(); pkg != "" {
+		packageName = packageIdentifier(path.Base(pkg))
+	}
 	if packageName == "" {
 		packageName = "schema"
 	}
 	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n%s%s", copyright, packageName, importPackage, gen.Field)))
 	if err != nil {
-		f.WriteString(fmt.Sprintf("package %s\n%s%s", packageName, importPackage, gen.Field))
-		return err
+		// keep the unformatted code for inspection
+		if err := gen.writeFile(".go", []byte(fmt.Sprintf("package %s\n%s%s", packageName, importPackage, gen.Field))); err != nil {
+			return err
+		}
+		return fmt.Errorf("xgen: format generated code %s: %w", gen.FileWithExtension(".go"), err)
 	}
-	f.Write(source)
-	return err
+	return gen.writeFile(".go", source)
 }
 
 func splitter(r rune) bool {
 	return strings.ContainsRune(":.-_", r)
 }
 
-func genGoFieldName(name string, unique bool) (fieldName string) {
+func genGoFieldName(name string) (fieldName string) {
 	for _, str := range strings.FieldsFunc(name, splitter) {
 		fieldName += MakeFirstUpperCase(str)
 	}
 
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
@@ -144,20 +243,632 @@ func genGoFieldType(name string) string {
 	return "interface{}"
 }
 
+// genGoFieldTypeByName resolves the Go field type for the given schema type
+// name. References to enumerated simple types keep the generated named type,
+// so the enumeration constants and checks apply to the field. With validation
+// enabled, references to the simple types with facets also keep the named
+// type to use its Validate method. Other types are resolved to their base
+// type.
+func (gen *CodeGenerator) genGoFieldTypeByName(name string) string {
+	if v := gen.simpleType(trimNSPrefix(name)); v != nil {
+		if gen.isGoEnumSimpleType(v) || (gen.Validation && !v.Union && v.Restriction.hasFacets()) {
+			return genGoFieldName(v.Name)
+		}
+	}
+	return genGoFieldType(gen.baseType(trimNSPrefix(name)))
+}
+
+// goPackageNames are the names of the packages imported by the generated Go
+// code, which can't be the aliases of the packages of the namespaces.
+var goPackageNames = []string{"fmt", "regexp", "time", "xml", "xsd"}
+
+// genGoQualifiedType qualifies the Go field type of the schema type with the
+// qualified name by the alias of its package, if the namespace of the type is
+// mapped to another package than the generated file.
+func (gen *CodeGenerator) genGoQualifiedType(name xml.Name, fieldType string) string {
+	pkg := gen.foreignPackage(name)
+	local := strings.TrimLeft(fieldType, "*[]")
+	if pkg == "" || isGoBuiltInType(local) || local == "interface{}" {
+		return fieldType
+	}
+	return fieldType[:len(fieldType)-len(local)] + gen.packageAlias(pkg, path.Base(pkg), goPackageNames...) + "." + local
+}
+
+// importGoType imports the package of the built-in Go type, which is the time
+// package or the xsd runtime package for the date and time types.
+func (gen *CodeGenerator) importGoType(fieldType string) {
+	switch fieldType = strings.TrimLeft(fieldType, "*[]"); {
+	case fieldType == "time.Time":
+		gen.ImportTime = true
+	case strings.HasPrefix(fieldType, "xsd.") && isGoBuiltInType(fieldType):
+		gen.ImportXSD = true
+	}
+}
+
+// genGoFieldTypeOf resolves the Go field type of the schema type with the
+// qualified name like genGoFieldTypeByName. The simple types declared in the
+// package of another namespace keep the generated named type of the package
+// for the same references, which are resolved to the base types by the
+// parser.
+func (gen *CodeGenerator) genGoFieldTypeOf(name xml.Name, schemaType string) string {
+	if pkg := gen.foreignPackage(name); pkg != "" && gen.Schema != nil {
+		if v := gen.Schema.SimpleType(name); v != nil && (gen.isGoEnumSimpleType(v) || (gen.Validation && !v.Union && v.Restriction.hasFacets())) {
+			return gen.packageAlias(pkg, path.Base(pkg), goPackageNames...) + "." + genGoFieldName(v.Name)
+		}
+	}
+	fieldType := gen.genGoQualifiedType(name, gen.genGoFieldTypeByName(schemaType))
+	gen.importGoType(fieldType)
+	return fieldType
+}
+
+// goWildcardElementType is the field type of the element wildcard, which
+// keeps the matched elements as raw XML with their names and attributes.
+const goWildcardElementType = "[]struct {\n\t\tXMLName\txml.Name\n\t\tAttrs\t[]xml.Attr\t`xml:\",any,attr\"`\n\t\tInnerXML\tstring\t`xml:\",innerxml\"`\n\t}"
+
+// genGoWildcardField returns the field of the element or attribute wildcard.
+// The elements matched by the element wildcard are kept as raw XML with
+// their names and attributes, and the attributes matched by the attribute
+// wildcard as the list of XML attributes.
+func (gen *CodeGenerator) genGoWildcardField(attribute bool) string {
+	gen.ImportEncodingXML = true
+	if attribute {
+		return "\tAnyAttrs\t[]xml.Attr\t`xml:\",any,attr\"`\n"
+	}
+	return "\tAny\t" + goWildcardElementType + "\t`xml:\",any\"`\n"
+}
+
+// genGoSubstitutionField returns the field of the element which references
+// the head of a substitution group. The field holds the elements in the
+// substitution group by the generated type, which dispatches on the element
+// name to decode the element with the type of the member.
+func (gen *CodeGenerator) genGoSubstitutionField(element *Element, members []*Element) string {
+	return fmt.Sprintf("\t%s\t%s\t`xml:\",any\"`\n", genGoFieldName(element.Name), gen.genGoSubstitutionType(element, members))
+}
+
+// genGoSubstitutionType generates the type of the elements in the
+// substitution group of the head referenced by the element, and returns the
+// field type of the element.
+func (gen *CodeGenerator) genGoSubstitutionType(element *Element, members []*Element) string {
+	typeName := genGoFieldName(trimNSPrefix(element.Name)) + "Substitution"
+	if _, ok := gen.StructAST[typeName]; !ok {
+		gen.ImportEncodingXML, gen.ImportFmt = true, true
+		var cases string
+		names := make(map[string]bool)
+		for _, member := range members {
+			name := trimNSPrefix(member.Name)
+			if names[name] {
+				continue
+			}
+			names[name] = true
+			cases += fmt.Sprintf("\tcase %q:\n\t\tv.Value = new(%s)\n", name, strings.TrimPrefix(gen.genGoFieldTypeOf(member.TypeName, member.Type), "*"))
+		}
+		gen.StructAST[typeName] = " struct {\n\tXMLName\txml.Name\n\tValue\tinterface{}\n}\n"
+		output := fmt.Sprintf("// %s holds an element in the substitution group of %s.\ntype %s%s", typeName, trimNSPrefix(element.Name), typeName, gen.StructAST[typeName])
+		output += fmt.Sprintf("\n// UnmarshalXML implements the xml.Unmarshaler interface and decodes the\n// element with the type of the member in the substitution group.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tswitch start.Name.Local {\n%s\tdefault:\n\t\treturn fmt.Errorf(\"unexpected element %%s in substitution group of %s\", start.Name.Local)\n\t}\n\tv.XMLName = start.Name\n\treturn d.DecodeElement(v.Value, &start)\n}\n", typeName, cases, trimNSPrefix(element.Name))
+		output += fmt.Sprintf("\n// MarshalXML implements the xml.Marshaler interface and encodes the element\n// with its name.\nfunc (v *%s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tstart.Name = v.XMLName\n\treturn e.EncodeElement(v.Value, start)\n}\n", typeName)
+		if gen.Hook != nil {
+			gen.Hook.OnAddContent(gen, &output)
+		}
+		gen.Field += output
+	}
+	if element.Plural {
+		return "[]" + typeName
+	}
+	return "*" + typeName
+}
+
+// isGoAnyElement reports whether the field of the element holds the elements
+// which are not matched by the names of the other fields: the element
+// wildcard and the element referencing the head of a substitution group.
+func (gen *CodeGenerator) isGoAnyElement(element *Element) bool {
+	return element.Wildcard || gen.substitutionGroup(element) != nil
+}
+
+// genGoElementsField returns the field which holds the elements of the
+// element wildcard and the substitution groups of the type, or an empty
+// string if the type has less than two of them. encoding/xml decodes all the
+// unmatched elements into the first field with the any option, so the
+// generated type of the field dispatches the elements to the wildcard and the
+// substitution groups by their names.
+func (gen *CodeGenerator) genGoElementsField(typeName string, elements []Element) string {
+	var fields, cases, wildcard, encodes string
+	var count int
+	seen := make(map[string]bool)
+	for i := range elements {
+		element := &elements[i]
+		if element.Wildcard {
+			if wildcard == "" {
+				fields += "\tAny\t" + goWildcardElementType + "\n"
+				wildcard, encodes = "\treturn d.DecodeElement(&v.Any, &start)\n", encodes+"v.Any, "
+			}
+			count++
+			continue
+		}
+		members := gen.substitutionGroup(element)
+		if members == nil {
+			continue
+		}
+		count++
+		fieldName := genGoFieldName(element.Name)
+		fields += fmt.Sprintf("\t%s\t%s\n", fieldName, gen.genGoSubstitutionType(element, members))
+		var names []string
+		for _, member := range members {
+			if name := trimNSPrefix(member.Name); !seen[name] {
+				seen[name] = true
+				names = append(names, fmt.Sprintf("%q", name))
+			}
+		}
+		if len(names) > 0 {
+			cases += fmt.Sprintf("\tcase %s:\n\t\treturn d.DecodeElement(&v.%s, &start)\n", strings.Join(names, ", "), fieldName)
+		}
+		encodes += "v." + fieldName + ", "
+	}
+	if count < 2 {
+		return ""
+	}
+	gen.ImportEncodingXML = true
+	if wildcard == "" {
+		gen.ImportFmt = true
+		wildcard = fmt.Sprintf("\treturn fmt.Errorf(\"unexpected element %%s in %s\", start.Name.Local)\n", typeName)
+	}
+	elementsType := gen.uniqueName(typeName + "Elements")
+	gen.StructAST[elementsType] = " struct {\n" + fields + "}\n"
+	output := fmt.Sprintf("// %s holds the elements of %s in the\n// substitution groups and the wildcard, which are dispatched to the fields\n// by their names.\ntype %s%s", elementsType, typeName, elementsType, gen.StructAST[elementsType])
+	output += fmt.Sprintf("\n// UnmarshalXML implements the xml.Unmarshaler interface and decodes the\n// element into the field of its substitution group or the wildcard.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tswitch start.Name.Local {\n%s\t}\n%s}\n", elementsType, cases, wildcard)
+	output += fmt.Sprintf("\n// MarshalXML implements the xml.Marshaler interface and encodes the elements\n// of the fields in order.\nfunc (v *%s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tfor _, field := range []interface{}{%s} {\n\t\tif err := e.Encode(field); err != nil {\n\t\t\treturn err\n\t\t}\n\t}\n\treturn nil\n}\n", elementsType, strings.TrimSuffix(encodes, ", "))
+	if gen.Hook != nil {
+		gen.Hook.OnAddContent(gen, &output)
+	}
+	gen.Field += output
+	return fmt.Sprintf("\tElements\t*%s\t`xml:\",any,omitempty\"`\n", elementsType)
+}
+
+// genGoXMLName returns the name in the xml tag of the element or attribute,
+// which is the local name prefixed by the namespace of the qualified ones.
+func genGoXMLName(namespace, name string) string {
+	if namespace == "" {
+		return trimNSPrefix(name)
+	}
+	return namespace + " " + trimNSPrefix(name)
+}
+
+// genGoAlternativeField returns the field type of the element whose
+// declaration has the type alternatives, or an empty string for the others.
+func (gen *CodeGenerator) genGoAlternativeField(element *Element) string {
+	decl := gen.alternativeDeclaration(element)
+	if decl == nil {
+		return ""
+	}
+	typeName := gen.genGoAlternativeType(decl)
+	if typeName == "" {
+		return ""
+	}
+	if element.Plural {
+		return "[]" + typeName
+	}
+	return "*" + typeName
+}
+
+// genGoAlternativeType generates the type which holds the element with the
+// type selected by the type alternatives of the declaring element, and
+// returns the name of it. It returns an empty string if any test is beyond
+// the XPath subset supported by goAlternativeTest, the field keeps the
+// declared type of the element in that case.
+func (gen *CodeGenerator) genGoAlternativeType(decl *Element) string {
+	typeName := genGoFieldName(trimNSPrefix(decl.Name)) + "Alternative"
+	if _, ok := gen.StructAST[typeName]; ok {
+		return typeName
+	}
+	var cases string
+	defaultType, defaultTypeName := decl.Type, decl.TypeName
+	for _, alternative := range decl.Alternatives {
+		if alternative.Test == "" {
+			defaultType, defaultTypeName = alternative.Type, alternative.TypeName
+			break
+		}
+		cond, ok := goAlternativeTest(alternative.Test)
+		if !ok {
+			return ""
+		}
+		cases += fmt.Sprintf("\t// %s\n\tcase %s:\n\t\tv.Value = new(%s)\n", strings.Join(strings.Fields(alternative.Test), " "), cond, gen.genGoAlternativeValueType(alternative.TypeName, alternative.Type))
+	}
+	selection := "\treturn d.Skip()\n"
+	if defaultType != "" {
+		selection = fmt.Sprintf("\tv.Value = new(%s)\n", gen.genGoAlternativeValueType(defaultTypeName, defaultType))
+	}
+	if cases != "" {
+		selection = fmt.Sprintf("\tattrs := make(map[string]*string, len(start.Attr))\n\tfor i := range start.Attr {\n\t\tattrs[start.Attr[i].Name.Local] = &start.Attr[i].Value\n\t}\n\tswitch {\n%s\tdefault:\n\t%s\t}\n", cases, selection)
+	}
+	gen.ImportEncodingXML = true
+	gen.StructAST[typeName] = " struct {\n\tValue\tinterface{}\n}\n"
+	output := fmt.Sprintf("\n// %s holds the %s element with the type\n// selected by its type alternatives.\ntype %s%s", typeName, trimNSPrefix(decl.Name), typeName, gen.StructAST[typeName])
+	output += fmt.Sprintf("\n// UnmarshalXML implements the xml.Unmarshaler interface and decodes the\n// element with the type of the first alternative whose test is true for the\n// attributes of it.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n%s\treturn d.DecodeElement(v.Value, &start)\n}\n", typeName, selection)
+	output += fmt.Sprintf("\n// MarshalXML implements the xml.Marshaler interface and encodes the element\n// with the value of the selected type.\nfunc (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\treturn e.EncodeElement(v.Value, start)\n}\n", typeName)
+	if gen.Hook != nil {
+		gen.Hook.OnAddContent(gen, &output)
+	}
+	gen.Field += output
+	return typeName
+}
+
+// genGoAlternativeValueType returns the Go type of the value allocated for the
+// type alternative.
+func (gen *CodeGenerator) genGoAlternativeValueType(name xml.Name, schemaType string) string {
+	valueType := strings.TrimPrefix(gen.genGoFieldTypeOf(name, schemaType), "*")
+	return valueType
+}
+
+// xpathOperand is an operand of the comparison in the test of the type
+// alternative, which is either an attribute or a string literal.
+type xpathOperand struct {
+	attr, literal string
+}
+
+// xpathParser parses the tokens of the test of the type alternative.
+type xpathParser struct {
+	tokens []string
+	pos    int
+}
+
+// goAlternativeTest compiles the XPath test expression of the type
+// alternative to the Go condition on the attrs map of the start element. The
+// supported subset is the comparisons of the attributes with each other and
+// with the string literals by the =, !=, eq and ne operators, the tests of
+// the presence of the attributes, the and, or and not() operators, and the
+// parentheses.
+func goAlternativeTest(test string) (string, bool) {
+	tokens, ok := tokenizeXPath(test)
+	if !ok || len(tokens) == 0 {
+		return "", false
+	}
+	p := xpathParser{tokens: tokens}
+	if cond, ok := p.or(); ok && p.pos == len(p.tokens) {
+		return cond, true
+	}
+	return "", false
+}
+
+// tokenizeXPath splits the XPath expression into the parentheses, operators,
+// string literals and names.
+func tokenizeXPath(expr string) (tokens []string, ok bool) {
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '=' || c == '@':
+			tokens = append(tokens, expr[i:i+1])
+			i++
+		case strings.HasPrefix(expr[i:], "!="):
+			tokens = append(tokens, "!=")
+			i += 2
+		case c == '\'' || c == '"':
+			j := i + 1
+			for {
+				k := strings.IndexByte(expr[j:], c)
+				if k < 0 {
+					return nil, false
+				}
+				j += k + 1
+				// the quotes are escaped by doubling them
+				if j == len(expr) || expr[j] != c {
+					break
+				}
+				j++
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(expr) && (unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j])) || strings.IndexByte("-_.:", expr[j]) >= 0) {
+				j++
+			}
+			if j == i {
+				return nil, false
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		}
+	}
+	return tokens, true
+}
+
+func (p *xpathParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *xpathParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *xpathParser) or() (string, bool) {
+	cond, ok := p.and()
+	for ok && p.peek() == "or" {
+		p.pos++
+		var right string
+		right, ok = p.and()
+		cond += " || " + right
+	}
+	return cond, ok
+}
+
+func (p *xpathParser) and() (string, bool) {
+	cond, ok := p.unary()
+	for ok && p.peek() == "and" {
+		p.pos++
+		var right string
+		right, ok = p.unary()
+		cond += " && " + right
+	}
+	return cond, ok
+}
+
+func (p *xpathParser) unary() (string, bool) {
+	switch p.peek() {
+	case "not", "fn:not":
+		p.pos++
+		if p.next() != "(" {
+			return "", false
+		}
+		cond, ok := p.or()
+		if !ok || p.next() != ")" {
+			return "", false
+		}
+		return "!(" + cond + ")", true
+	case "(":
+		p.pos++
+		cond, ok := p.or()
+		if !ok || p.next() != ")" {
+			return "", false
+		}
+		return "(" + cond + ")", true
+	}
+	left, ok := p.operand()
+	if !ok {
+		return "", false
+	}
+	var op string
+	switch p.peek() {
+	case "=", "eq":
+		op = "=="
+	case "!=", "ne":
+		op = "!="
+	default:
+		if left.attr == "" {
+			return "", false
+		}
+		return fmt.Sprintf("attrs[%q] != nil", left.attr), true
+	}
+	p.pos++
+	right, ok := p.operand()
+	if !ok {
+		return "", false
+	}
+	if left.attr == "" {
+		left, right = right, left
+	}
+	switch {
+	case left.attr == "":
+		return "", false
+	case right.attr == "":
+		return fmt.Sprintf("attrs[%q] != nil && *attrs[%q] %s %q", left.attr, left.attr, op, right.literal), true
+	}
+	return fmt.Sprintf("attrs[%q] != nil && attrs[%q] != nil && *attrs[%q] %s *attrs[%q]", left.attr, right.attr, left.attr, op, right.attr), true
+}
+
+func (p *xpathParser) operand() (operand xpathOperand, ok bool) {
+	token := p.next()
+	switch {
+	case token == "@":
+		operand.attr = trimNSPrefix(p.next())
+	case strings.HasPrefix(token, "attribute::"):
+		operand.attr = trimNSPrefix(strings.TrimPrefix(token, "attribute::"))
+	case strings.HasPrefix(token, "'") || strings.HasPrefix(token, `"`):
+		quote := token[:1]
+		operand.literal = strings.ReplaceAll(token[1:len(token)-1], quote+quote, quote)
+		return operand, true
+	}
+	return operand, operand.attr != "" && strings.IndexByte(operand.attr, ':') < 0
+}
+
+// genGoDefault returns the declaration of the variable which holds the
+// default value of the pointer field, and the key-value pair which sets the
+// field in the composite literal of the constructor. The check compares the
+// field with the fixed value. Nothing is returned for the values which can't
+// be represented by the literal of the field type. The presence specifies how
+// the optional field holds the value of the field type.
+func (gen *CodeGenerator) genGoDefault(typeName, field, schemaType, fieldType, value string, presence goPresence, fixed bool) (decl, init, check string) {
+	underlying := fieldType
+	if !isGoBuiltInType(fieldType) {
+		v := gen.simpleType(trimNSPrefix(schemaType))
+		if v == nil || v.List || v.Union || strings.HasPrefix(fieldType, "*") {
+			return
+		}
+		underlying = genGoFieldType(gen.baseType(trimNSPrefix(v.Base)))
+	}
+	if underlying != "string" {
+		value = strings.TrimSpace(value)
+	}
+	literal, ok := goEnumLiteral(underlying, value)
+	if !ok {
+		return
+	}
+	init = fmt.Sprintf("\t\t%s:\t%s,\n", field, literal)
+	if fixed {
+		gen.ImportFmt = true
+		// the absent values are left to the required checks
+		value := "v." + field
+		switch presence {
+		case goPointer:
+			value = "*" + value
+			check = fmt.Sprintf("v.%s != nil && ", field)
+		case goOptional:
+			value += ".Value"
+			check = fmt.Sprintf("v.%s.Valid && ", field)
+		default:
+			zero := "0"
+			switch underlying {
+			case "string":
+				zero = `""`
+			case "bool":
+				zero = "false"
+			}
+			check = fmt.Sprintf("v.%s != %s && ", field, zero)
+		}
+		check = fmt.Sprintf("\tif %s%s != %s {\n\t\treturn fmt.Errorf(\"%s.%s: value %%v is not the fixed value %%v\", %s, %s)\n\t}\n", check, value, literal, typeName, field, value, literal)
+	}
+	if presence == goOptional {
+		init = fmt.Sprintf("\t\t%s:\txsd.Some[%s](%s),\n", field, fieldType, literal)
+	}
+	if presence == goPointer {
+		variable := "default" + field
+		if fieldType != underlying || (underlying != "string" && underlying != "bool" && underlying != "int") {
+			literal = fmt.Sprintf("%s(%s)", fieldType, literal)
+		}
+		decl = fmt.Sprintf("\t%s := %s\n", variable, literal)
+		init = fmt.Sprintf("\t\t%s:\t&%s,\n", field, variable)
+	}
+	return
+}
+
+// genGoConstructor generates the constructor of the struct, which returns the
+// struct with the default and fixed values of the XML schema.
+func genGoConstructor(typeName, decls, inits string) string {
+	return fmt.Sprintf("\n// New%s returns a new %s with the default values of the XML schema.\nfunc New%s() *%s {\n%s\treturn &%s{\n%s\t}\n}\n", typeName, typeName, typeName, typeName, decls, typeName, inits)
+}
+
+// isGoEnumSimpleType returns true if the Go code generated for the given
+// simple type includes enumeration constants.
+func (gen *CodeGenerator) isGoEnumSimpleType(v *SimpleType) bool {
+	if v.List || v.Union || len(v.Restriction.Enum) == 0 {
+		return false
+	}
+	_, ok := goEnumLiteral(genGoFieldType(gen.baseType(trimNSPrefix(v.Base))), v.Restriction.Enum[0])
+	return ok
+}
+
+// goEnumLiteral returns the Go constant literal of the enumeration value for
+// the given underlying type.
+func goEnumLiteral(fieldType, value string) (string, bool) {
+	switch fieldType {
+	case "string":
+		return strconv.Quote(value), true
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatBool(b), true
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", false
+		}
+		return value, true
+	case "int", "int8", "int16", "int32", "int64":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", false
+		}
+		return value, true
+	case "byte", "uint", "uint8", "uint16", "uint32", "uint64":
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return "", false
+		}
+		return value, true
+	}
+	return "", false
+}
+
+// genGoEnumName returns the constant name of the enumeration value for the
+// given type name.
+func genGoEnumName(typeName, value string) string {
+	constName := typeName
+	for _, str := range strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		constName += MakeFirstUpperCase(str)
+	}
+	return constName
+}
+
+// genGoEnum generates the constants and the helper methods for the
+// enumerated simple type in Go language syntax.
+func (gen *CodeGenerator) genGoEnum(typeName, fieldType string, enum []string) string {
+	var constants, values string
+	constNames, seen := map[string]bool{}, map[string]bool{}
+	for i, value := range enum {
+		literal, ok := goEnumLiteral(fieldType, value)
+		if !ok || seen[value] {
+			continue
+		}
+		seen[value] = true
+		constName := genGoEnumName(typeName, value)
+		if constName == typeName || constNames[constName] {
+			constName = fmt.Sprintf("%sEnum%d", typeName, i+1)
+		}
+		constNames[constName] = true
+		constants += fmt.Sprintf("\t%s\t%s\t= %s\n", constName, typeName, literal)
+		values += fmt.Sprintf("\t\t%s,\n", constName)
+	}
+	gen.ImportFmt = true
+	output := fmt.Sprintf("\n// Enumeration values of %s.\nconst (\n%s)\n", typeName, constants)
+	output += fmt.Sprintf("\n// Values returns the enumeration values of %s.\nfunc (v %s) Values() []%s {\n\treturn []%s{\n%s\t}\n}\n", typeName, typeName, typeName, typeName, values)
+	output += fmt.Sprintf("\n// IsValid reports whether v is one of the enumeration values of %s.\nfunc (v %s) IsValid() bool {\n\tfor _, value := range v.Values() {\n\t\tif v == value {\n\t\t\treturn true\n\t\t}\n\t}\n\treturn false\n}\n", typeName, typeName)
+	parse := fmt.Sprintf("\tvalue := %s(text)\n", typeName)
+	if fieldType != "string" {
+		parse = fmt.Sprintf("\tvar value %s\n\tif _, err := fmt.Sscan(string(text), &value); err != nil {\n\t\treturn fmt.Errorf(\"invalid value %%q for %s: %%w\", text, err)\n\t}\n", typeName, typeName)
+	}
+	output += fmt.Sprintf("\n// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects\n// values which are not one of the enumeration values of %s.\nfunc (v *%s) UnmarshalText(text []byte) error {\n%s\tif !value.IsValid() {\n\t\treturn fmt.Errorf(\"invalid value %%q for %s\", text)\n\t}\n\t*v = value\n\treturn nil\n}\n", typeName, typeName, parse, typeName)
+	return output
+}
+
+// genGoUnionMemberType resolves the Go field type of the member type of a
+// union. The members of the list, union and enumerated simple types keep the
+// generated named type, so the text of the union is parsed by its methods.
+func (gen *CodeGenerator) genGoUnionMemberType(memberName, memberType string) string {
+	if v := gen.simpleType(memberName); v != nil && (v.List || v.Union) {
+		return genGoFieldName(v.Name)
+	}
+	if gen.simpleType(memberName) != nil {
+		return gen.genGoFieldTypeByName(memberName)
+	}
+	return genGoFieldType(memberType)
+}
+
+// genGoTextMethods generates the text marshalling methods of the list and
+// union simple types, which delegate to the given calls of the xsd runtime
+// package. The MarshalXMLAttr method omits the attribute with an empty text.
+func (gen *CodeGenerator) genGoTextMethods(typeName, marshalDoc, marshal, unmarshalDoc, unmarshal string) string {
+	gen.ImportEncodingXML, gen.ImportXSD = true, true
+	output := fmt.Sprintf("\n// MarshalText implements the encoding.TextMarshaler interface and\n// %s.\nfunc (v %s) MarshalText() ([]byte, error) {\n\treturn %s\n}\n", marshalDoc, typeName, marshal)
+	output += fmt.Sprintf("\n// UnmarshalText implements the encoding.TextUnmarshaler interface and\n// %s.\nfunc (v *%s) UnmarshalText(text []byte) error {\n\treturn %s\n}\n", unmarshalDoc, typeName, unmarshal)
+	output += fmt.Sprintf("\n// MarshalXMLAttr implements the xml.MarshalerAttr interface and omits the\n// attribute if %s has an empty text.\nfunc (v %s) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {\n\ttext, err := v.MarshalText()\n\tif err != nil || len(text) == 0 {\n\t\treturn xml.Attr{}, err\n\t}\n\treturn xml.Attr{Name: name, Value: string(text)}, nil\n}\n", typeName, typeName)
+	return output
+}
+
 // GoSimpleType generates code for simple type XML schema in Go language
 // syntax.
 func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			if fieldType == "time.Time" {
-				gen.ImportTime = true
-			}
+			fieldType := genGoFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			gen.importGoType(fieldType)
 			content := fmt.Sprintf(" []%s\n", genGoFieldType(fieldType))
 			gen.StructAST[v.Name] = content
-			fieldName := genGoFieldName(v.Name, true)
+			fieldName := gen.uniqueName(genGoFieldName(v.Name))
 
-			output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			output := fmt.Sprintf("%stype %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
+			output += gen.genGoTextMethods(fieldName,
+				"returns the items of "+fieldName+" separated by spaces", "xsd.MarshalList(v)",
+				"parses the whitespace-separated items of "+fieldName, "xsd.UnmarshalList(text, v)")
+			if gen.Validation {
+				checks, decls := gen.genGoFacetChecks(&v.Restriction, "[]"+genGoFieldType(fieldType), "v", fieldName, "pattern"+fieldName)
+				output += decls + genGoValueValidate(fieldName, checks)
+			}
 			if gen.Hook != nil {
 				gen.Hook.OnAddContent(gen, &output)
 			}
@@ -168,24 +879,30 @@ func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 	if v.Union && len(v.MemberTypes) > 0 {
 		if _, ok := gen.StructAST[v.Name]; !ok {
 			content := " struct {\n"
-			fieldName := genGoFieldName(v.Name, true)
+			fieldName := gen.uniqueName(genGoFieldName(v.Name))
 			if fieldName != v.Name {
 				gen.ImportEncodingXML = true
 				content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 			}
-			for _, member := range toSortedPairs(v.MemberTypes) {
-				memberName := member.key
-				memberType := member.value
-
+			var members []string
+			for _, memberName := range v.Members {
+				memberType := v.MemberTypes[memberName]
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(memberName, false), genGoFieldType(memberType))
+				content += fmt.Sprintf("\t%s\t*%s\n", genGoFieldName(memberName), strings.TrimPrefix(gen.genGoUnionMemberType(memberName, memberType), "*"))
+				members = append(members, ", &v."+genGoFieldName(memberName))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
 
-			output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			output := fmt.Sprintf("%stype %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
+			output += gen.genGoTextMethods(fieldName,
+				"returns the text of the member of "+fieldName+" which holds the value", "xsd.MarshalUnion("+strings.ReplaceAll(strings.TrimPrefix(strings.Join(members, ""), ", "), "&", "")+")",
+				"sets the first member of "+fieldName+" which accepts the text in\n// declaration order", "xsd.UnmarshalUnion(text"+strings.Join(members, "")+")")
+			if gen.Validation {
+				output += genGoStructValidate(fieldName, "")
+			}
 			if gen.Hook != nil {
 				gen.Hook.OnAddContent(gen, &output)
 			}
@@ -194,11 +911,32 @@ func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := fmt.Sprintf(" %s\n", genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree)))
+		fieldType := genGoFieldType(gen.baseType(trimNSPrefix(v.Base)))
+		content := fmt.Sprintf(" %s\n", fieldType)
 		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 
-		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		output := fmt.Sprintf("%stype %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
+		restriction := v.Restriction
+		if gen.importGoType(fieldType); strings.HasPrefix(fieldType, "xsd.") && isGoBuiltInType(fieldType) {
+			// the defined type doesn't have the methods of the xsd runtime type
+			output += gen.genGoTextMethods(fieldName,
+				"returns the text of "+fieldType, fieldType+"(v).MarshalText()",
+				"parses the text as "+fieldType, "(*"+fieldType+")(v).UnmarshalText(text)")
+		}
+		isEnum := gen.isGoEnumSimpleType(v)
+		if isEnum {
+			output += gen.genGoEnum(fieldName, fieldType, v.Restriction.Enum)
+			// the enumeration values are checked by the IsValid method
+			restriction.Enum = nil
+		}
+		if gen.Validation {
+			checks, decls := gen.genGoFacetChecks(&restriction, fieldType, "v", fieldName, "pattern"+fieldName)
+			if isEnum {
+				checks = fmt.Sprintf("\tif !v.IsValid() {\n\t\treturn fmt.Errorf(\"%s: invalid value %%v\", v)\n\t}\n", fieldName) + checks
+			}
+			output += decls + genGoValueValidate(fieldName, checks)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
@@ -211,73 +949,135 @@ func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
-		if fieldName != v.Name {
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
+		var namespace string
+		if v.Element && gen.Schema != nil {
+			namespace = gen.Schema.TargetNamespace
+		}
+		if fieldName != v.Name || namespace != "" {
 			gen.ImportEncodingXML = true
-			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
+			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", genGoXMLName(namespace, v.Name))
 		}
+		var checks, decls, defaultDecls, defaultInits, fixedChecks string
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			if fieldType == "time.Time" {
-				gen.ImportTime = true
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			gen.importGoType(fieldType)
+			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(attrGroup.Name), gen.genGoQualifiedType(attrGroup.RefName, genGoFieldType(fieldType)))
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(attrGroup.Name), attrGroup.Name, gen.genGoQualifiedType(attrGroup.RefName, genGoFieldType(fieldType)), &Restriction{}, false, goRequired, false)
+				checks, decls = checks+check, decls+decl
 			}
-			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(attrGroup.Name, false), genGoFieldType(fieldType))
 		}
 
 		for _, attribute := range v.Attributes {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+			if attribute.Wildcard {
+				content += gen.genGoWildcardField(true)
+				continue
+			}
+			fieldType := gen.genGoFieldTypeOf(typeQName(attribute.TypeName, attribute.Ref), attribute.Type)
+			attributeType := fieldType
 			var optional string
+			var presence goPresence
 			if attribute.Optional {
-				if !strings.HasPrefix(fieldType, `*`) {
-					fieldType = "*" + fieldType
-				} else {
-					optional = `,omitempty`
-				}
+				fieldType, presence = gen.genGoOptionalType(fieldType)
+				optional = `,omitempty`
 			}
-			if fieldType == "time.Time" {
-				gen.ImportTime = true
+			gen.importGoType(fieldType)
+			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name), fieldType, genGoXMLName(attribute.TargetNamespace, attribute.Name), optional)
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(attribute.Name)+"Attr", attribute.Name, attributeType, &attribute.Restriction, false, presence, false)
+				checks, decls = checks+check, decls+decl
+			}
+			if attribute.Default != "" {
+				decl, init, check := gen.genGoDefault(fieldName, genGoFieldName(attribute.Name)+"Attr", attribute.Type, attributeType, attribute.Default, presence, attribute.Fixed)
+				defaultDecls, defaultInits, fixedChecks = defaultDecls+decl, defaultInits+init, fixedChecks+check
 			}
-			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name, false), fieldType, attribute.Name, optional)
 		}
 		for _, group := range v.Groups {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genGoQualifiedType(group.RefName, genGoFieldType(gen.baseType(trimNSPrefix(group.Ref))))
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(group.Name), group.Name, fieldType, &Restriction{}, group.Plural, goRequired, false)
+				checks, decls = checks+check, decls+decl
+			}
 			if group.Plural {
 				fieldType = "[]" + fieldType
 			}
-			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(group.Name, false), fieldType)
+			content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(group.Name), fieldType)
 		}
 
+		elements := gen.genGoElementsField(fieldName, v.Elements)
+		dispatch := elements != ""
 		for _, element := range v.Elements {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			if dispatch && gen.isGoAnyElement(&element) {
+				content, elements = content+elements, ""
+				continue
+			}
+			if element.Wildcard {
+				content += gen.genGoWildcardField(false)
+				continue
+			}
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genGoSubstitutionField(&element, members)
+				continue
+			}
+			if typeName := gen.genGoAlternativeField(&element); typeName != "" {
+				content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s\"`\n", genGoFieldName(element.Name), typeName, genGoXMLName(element.TargetNamespace, element.Name))
+				continue
+			}
+			fieldType := gen.genGoFieldTypeOf(typeQName(element.TypeName, element.Ref), element.Type)
+			elementType := fieldType
 
 			if element.Plural {
 				fieldType = "[]" + fieldType
 			}
 			var optional string
+			var presence goPresence
 			if element.Optional {
-				if !element.Plural && !strings.HasPrefix(fieldType, `*`) {
-					fieldType = "*" + fieldType
-				}
+				fieldType, presence = gen.genGoOptionalType(fieldType)
+				optional = `,omitempty`
 			}
-			if fieldType == "time.Time" {
-				gen.ImportTime = true
+			gen.importGoType(fieldType)
+			content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s%s\"`\n", genGoFieldName(element.Name), fieldType, genGoXMLName(element.TargetNamespace, element.Name), optional)
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(element.Name), element.Name, elementType, &element.Restriction, element.Plural, presence, !element.Optional)
+				checks, decls = checks+check, decls+decl
+			}
+			if element.Default != "" && !element.Plural {
+				decl, init, check := gen.genGoDefault(fieldName, genGoFieldName(element.Name), element.Type, elementType, element.Default, presence, element.Fixed)
+				defaultDecls, defaultInits, fixedChecks = defaultDecls+decl, defaultInits+init, fixedChecks+check
 			}
-			content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s%s\"`\n", genGoFieldName(element.Name, false), fieldType, element.Name, optional)
 		}
 		if len(v.Base) > 0 {
 			// If the type is a built-in type, generate a Value field as chardata.
 			// If it's not built-in one, embed the base type in the struct for the child type
 			// to effectively inherit all of the base type's fields
 			if isGoBuiltInType(v.Base) {
-				content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", genGoFieldType(v.Base))
+				content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", gen.genGoQualifiedType(v.BaseName, genGoFieldType(v.Base)))
 			} else {
-				content += fmt.Sprintf("\t%s\n", genGoFieldType(v.Base))
+				content += fmt.Sprintf("\t%s\n", gen.genGoQualifiedType(v.BaseName, genGoFieldType(v.Base)))
+				if gen.Validation {
+					check, decl := gen.genGoFieldValidation(fieldName, strings.TrimPrefix(gen.genGoQualifiedType(v.BaseName, genGoFieldType(v.Base)), "*"), v.Base, gen.genGoQualifiedType(v.BaseName, genGoFieldType(v.Base)), &Restriction{}, false, goRequired, false)
+					checks, decls = checks+check, decls+decl
+				}
 			}
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
 
-		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		output := fmt.Sprintf("%stype %s%s", genTypeComment(fieldName, v.Doc, v.Assertions, "//"), fieldName, gen.StructAST[v.Name])
+		if defaultInits != "" {
+			output += genGoConstructor(fieldName, defaultDecls, defaultInits)
+		}
+		if gen.Validation {
+			constraints := gen.genGoIdentityConstraints("", v.IdentityConstraints)
+			for _, element := range v.Elements {
+				constraints += gen.genGoIdentityConstraints(element.Name, element.IdentityConstraints)
+			}
+			if constraints != "" {
+				checks += fmt.Sprintf("\tif err := xsd.CheckIdentityConstraints(v,\n%s\t); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n", constraints, fieldName)
+			}
+			output += decls + genGoStructValidate(fieldName, checks+fixedChecks)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
@@ -294,17 +1094,56 @@ func isGoBuiltInType(typeName string) bool {
 func (gen *CodeGenerator) GoGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 		if fieldName != v.Name {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 		}
+		var checks, decls, defaultDecls, defaultInits, fixedChecks string
+		elements := gen.genGoElementsField(fieldName, v.Elements)
+		dispatch := elements != ""
 		for _, element := range v.Elements {
-			var plural string
+			if dispatch && gen.isGoAnyElement(&element) {
+				content, elements = content+elements, ""
+				continue
+			}
+			if element.Wildcard {
+				content += gen.genGoWildcardField(false)
+				continue
+			}
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genGoSubstitutionField(&element, members)
+				continue
+			}
+			var tag string
+			if element.TargetNamespace != "" {
+				tag = fmt.Sprintf("\t`xml:\"%s\"`", genGoXMLName(element.TargetNamespace, element.Name))
+			}
+			if typeName := gen.genGoAlternativeField(&element); typeName != "" {
+				content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(element.Name), typeName, tag)
+				continue
+			}
+			elementType := gen.genGoFieldTypeOf(typeQName(element.TypeName, element.Ref), element.Type)
+			fieldType := elementType
 			if element.Plural {
-				plural = "[]"
+				fieldType = "[]" + fieldType
+			}
+			var optional string
+			var presence goPresence
+			if element.Optional {
+				fieldType, presence = gen.genGoOptionalType(fieldType)
+				optional = `,omitempty`
+			}
+			gen.importGoType(fieldType)
+			content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s%s\"`\n", genGoFieldName(element.Name), fieldType, genGoXMLName(element.TargetNamespace, element.Name), optional)
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(element.Name), element.Name, elementType, &element.Restriction, element.Plural, presence, !element.Optional)
+				checks, decls = checks+check, decls+decl
+			}
+			if element.Default != "" && !element.Plural {
+				decl, init, check := gen.genGoDefault(fieldName, genGoFieldName(element.Name), element.Type, elementType, element.Default, presence, element.Fixed)
+				defaultDecls, defaultInits, fixedChecks = defaultDecls+decl, defaultInits+init, fixedChecks+check
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(element.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)))
 		}
 
 		for _, group := range v.Groups {
@@ -312,13 +1151,23 @@ func (gen *CodeGenerator) GoGroup(v *Group) {
 			if group.Plural {
 				plural = "[]"
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)))
+			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name), plural, gen.genGoQualifiedType(group.RefName, genGoFieldType(gen.baseType(trimNSPrefix(group.Ref)))))
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(group.Name), group.Name, gen.genGoQualifiedType(group.RefName, genGoFieldType(gen.baseType(trimNSPrefix(group.Ref)))), &Restriction{}, group.Plural, goRequired, false)
+				checks, decls = checks+check, decls+decl
+			}
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
 
 		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if defaultInits != "" {
+			output += genGoConstructor(fieldName, defaultDecls, defaultInits)
+		}
+		if gen.Validation {
+			output += decls + genGoStructValidate(fieldName, checks+fixedChecks)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
@@ -331,22 +1180,45 @@ func (gen *CodeGenerator) GoGroup(v *Group) {
 func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 		if fieldName != v.Name {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 		}
+		var checks, decls, defaultDecls, defaultInits, fixedChecks string
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += gen.genGoWildcardField(true)
+				continue
+			}
+			attributeType := gen.genGoFieldTypeOf(typeQName(attribute.TypeName, attribute.Ref), attribute.Type)
+			fieldType := attributeType
 			var optional string
+			var presence goPresence
 			if attribute.Optional {
+				fieldType, presence = gen.genGoOptionalType(fieldType)
 				optional = `,omitempty`
 			}
-			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name, false), genGoFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)), attribute.Name, optional)
+			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name), fieldType, genGoXMLName(attribute.TargetNamespace, attribute.Name), optional)
+			if gen.Validation {
+				check, decl := gen.genGoFieldValidation(fieldName, genGoFieldName(attribute.Name)+"Attr", attribute.Name, attributeType, &attribute.Restriction, false, presence, false)
+				checks, decls = checks+check, decls+decl
+			}
+			if attribute.Default != "" {
+				decl, init, check := gen.genGoDefault(fieldName, genGoFieldName(attribute.Name)+"Attr", attribute.Type, attributeType, attribute.Default, presence, attribute.Fixed)
+				defaultDecls, defaultInits, fixedChecks = defaultDecls+decl, defaultInits+init, fixedChecks+check
+			}
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
 
 		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if defaultInits != "" {
+			output += genGoConstructor(fieldName, defaultDecls, defaultInits)
+		}
+		if gen.Validation {
+			output += decls + genGoStructValidate(fieldName, checks+fixedChecks)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
@@ -361,15 +1233,22 @@ func (gen *CodeGenerator) GoElement(v *Element) {
 		if v.Plural {
 			plural = "[]"
 		}
-		content := fmt.Sprintf("\t%s%s\n", plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree)))
+		content := fmt.Sprintf("\t%s%s\n", plural, gen.genGoFieldTypeOf(typeQName(v.TypeName, v.Ref), v.Type))
 		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, false)
+		fieldName := genGoFieldName(v.Name)
 
 		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if gen.Validation {
+			output += gen.genGoDeclValidate(fieldName, gen.genGoFieldTypeOf(typeQName(v.TypeName, v.Ref), v.Type), &v.Restriction, v.Plural)
+			output += gen.genGoElementIdentityValidate(fieldName, v)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
 		gen.Field += output
+		if len(v.Alternatives) > 0 {
+			gen.genGoAlternativeType(v)
+		}
 	}
 }
 
@@ -380,11 +1259,14 @@ func (gen *CodeGenerator) GoAttribute(v *Attribute) {
 		if v.Plural {
 			plural = "[]"
 		}
-		content := fmt.Sprintf("\t%s%s\n", plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree)))
+		content := fmt.Sprintf("\t%s%s\n", plural, gen.genGoFieldTypeOf(typeQName(v.TypeName, v.Ref), v.Type))
 		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genGoFieldName(v.Name))
 
 		output := fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if gen.Validation {
+			output += gen.genGoDeclValidate(fieldName, gen.genGoFieldTypeOf(typeQName(v.TypeName, v.Ref), v.Type), &v.Restriction, v.Plural)
+		}
 		if gen.Hook != nil {
 			gen.Hook.OnAddContent(gen, &output)
 		}
@@ -401,3 +1283,249 @@ func (gen *CodeGenerator) FileWithExtension(extension string) string {
 	}
 	return gen.File + extension
 }
+
+// goNumericType defines the Go types whose values are compared with the
+// bounds of the range facets as numbers.
+var goNumericType = map[string]bool{
+	"byte":    true,
+	"float32": true,
+	"float64": true,
+	"int":     true,
+	"int8":    true,
+	"int16":   true,
+	"int32":   true,
+	"int64":   true,
+	"uint":    true,
+	"uint8":   true,
+	"uint16":  true,
+	"uint32":  true,
+	"uint64":  true,
+}
+
+// genGoFacetChecks generates the statements which check the value of the
+// given Go type against the facets of the restriction. The name identifies
+// the value in the error messages. The declarations of the compiled pattern
+// variables with the given name, numbered from the second one, are returned
+// when the restriction has pattern facets. The values of the other types than
+// string are matched against the patterns by their canonical lexical forms.
+func (gen *CodeGenerator) genGoFacetChecks(r *Restriction, fieldType, value, name, patternName string) (checks, decls string) {
+	var length string
+	switch {
+	case fieldType == "string":
+		length = fmt.Sprintf("len([]rune(string(%s)))", value)
+	case strings.HasPrefix(fieldType, "[]"):
+		length = fmt.Sprintf("len(%s)", value)
+	}
+	if length != "" && r.HasMinLength {
+		checks += fmt.Sprintf("\tif n := %s; n < %d {\n\t\treturn fmt.Errorf(\"%s: length %%d is less than %d\", n)\n\t}\n", length, r.MinLength, name, r.MinLength)
+	}
+	if length != "" && r.HasMaxLength {
+		checks += fmt.Sprintf("\tif n := %s; n > %d {\n\t\treturn fmt.Errorf(\"%s: length %%d is greater than %d\", n)\n\t}\n", length, r.MaxLength, name, r.MaxLength)
+	}
+	if r.HasMin {
+		operator, message := "<", "less than"
+		if r.MinExclusive {
+			operator, message = "<=", "less than or equal to"
+		}
+		checks += gen.genGoRangeCheck(fieldType, value, name, r.Min, operator, message)
+	}
+	if r.HasMax {
+		operator, message := ">", "greater than"
+		if r.MaxExclusive {
+			operator, message = ">=", "greater than or equal to"
+		}
+		checks += gen.genGoRangeCheck(fieldType, value, name, r.Max, operator, message)
+	}
+	for i, pattern := range r.Patterns {
+		gen.ImportRegexp = true
+		variable := patternName
+		if i > 0 {
+			variable += strconv.Itoa(i + 1)
+		}
+		decls += fmt.Sprintf("\nvar %s = regexp.MustCompile(%q)\n", variable, pattern.String())
+		if fieldType == "string" {
+			checks += fmt.Sprintf("\tif !%s.MatchString(string(%s)) {\n\t\treturn fmt.Errorf(\"%s: value %%q does not match pattern %%s\", %s, %s)\n\t}\n", variable, value, name, value, variable)
+			continue
+		}
+		gen.ImportXSD = true
+		checks += fmt.Sprintf("\tif text, err := xsd.FormatText(%s); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t} else if !%s.MatchString(text) {\n\t\treturn fmt.Errorf(\"%s: value %%q does not match pattern %%s\", text, %s)\n\t}\n", value, name, variable, name, variable)
+	}
+	if len(r.Enum) > 0 {
+		var literals []string
+		for _, enum := range r.Enum {
+			literal, ok := goEnumLiteral(fieldType, enum)
+			if !ok {
+				literals = nil
+				break
+			}
+			literals = append(literals, literal)
+		}
+		if len(literals) > 0 {
+			checks += fmt.Sprintf("\tswitch %s {\n\tcase %s:\n\tdefault:\n\t\treturn fmt.Errorf(\"%s: invalid value %%v\", %s)\n\t}\n", value, strings.Join(literals, ", "), name, value)
+		}
+	}
+	if checks != "" {
+		gen.ImportFmt = true
+	}
+	return
+}
+
+// genGoRangeCheck generates the statement which checks the value against the
+// bound of a range facet. The numbers are compared by genGoBoundCheck, and the
+// values of the date, time and duration types by their lexical forms at run
+// time. The list types have no range facets.
+func (gen *CodeGenerator) genGoRangeCheck(fieldType, value, name, bound, operator, message string) string {
+	if goNumericType[fieldType] {
+		return genGoBoundCheck(fieldType, value, name, bound, operator, message)
+	}
+	if strings.HasPrefix(fieldType, "[]") {
+		return ""
+	}
+	gen.ImportXSD = true
+	return fmt.Sprintf("\tif text, cmp, err := xsd.CompareText(%s, %q); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t} else if cmp %s 0 {\n\t\treturn fmt.Errorf(\"%s: value %%s is %s %s\", text)\n\t}\n", value, bound, name, operator, name, message, bound)
+}
+
+// genGoBoundCheck generates the statement which checks the value of the Go
+// numeric type against the lexical bound. The values of the integer types are
+// compared with the integer bounds as integers, since float64 can't hold all
+// of them exactly. It returns an empty string for the bounds which are not
+// finite numbers.
+func genGoBoundCheck(fieldType, value, name, bound, operator, message string) string {
+	expr := "float64(" + value + ")"
+	if n, err := strconv.ParseInt(bound, 10, 64); err == nil && strings.HasPrefix(fieldType, "int") {
+		expr, bound = "int64("+value+")", strconv.FormatInt(n, 10)
+	} else if n, err := strconv.ParseUint(strings.TrimPrefix(bound, "+"), 10, 64); err == nil && (strings.HasPrefix(fieldType, "uint") || fieldType == "byte") {
+		expr, bound = "uint64("+value+")", strconv.FormatUint(n, 10)
+	} else if f, err := strconv.ParseFloat(bound, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		bound = strconv.FormatFloat(f, 'g', -1, 64)
+	} else {
+		return ""
+	}
+	return fmt.Sprintf("\tif %s %s %s {\n\t\treturn fmt.Errorf(\"%s: value %%v is %s %s\", %s)\n\t}\n", expr, operator, bound, name, message, bound, value)
+}
+
+// genGoFieldValidation generates the statements which validate the field of
+// the generated struct. The field type is the type of the referenced schema
+// type, the presence specifies how the optional field holds the value of that
+// type, and the absent values are not checked.
+func (gen *CodeGenerator) genGoFieldValidation(typeName, field, xmlName, fieldType string, r *Restriction, plural bool, presence goPresence, required bool) (checks, decls string) {
+	name := typeName + "." + field
+	value := "v." + field
+	if required && presence == goRequired {
+		if plural {
+			checks += fmt.Sprintf("\tif len(%s) == 0 {\n\t\treturn fmt.Errorf(\"%s: missing required element %%q\", %q)\n\t}\n", value, typeName, xmlName)
+		} else if strings.HasPrefix(fieldType, "*") {
+			checks += fmt.Sprintf("\tif %s == nil {\n\t\treturn fmt.Errorf(\"%s: missing required element %%q\", %q)\n\t}\n", value, typeName, xmlName)
+		}
+	}
+	switch {
+	case plural:
+		value = "item"
+	case presence == goPointer:
+		value = "*" + value
+	case presence == goOptional:
+		value += ".Value"
+	}
+	var check string
+	switch {
+	case isGoBuiltInType(fieldType):
+		check, decls = gen.genGoFacetChecks(r, fieldType, value, name, "pattern"+typeName+field)
+	case fieldType != "interface{}":
+		check = fmt.Sprintf("\tif err := %s.Validate(); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n", strings.TrimPrefix(value, "*"), name)
+	}
+	if check == "" {
+		return
+	}
+	gen.ImportFmt = true
+	if plural {
+		return checks + fmt.Sprintf("\tfor _, item := range v.%s {\n%s\t}\n", field, check), decls
+	}
+	switch presence {
+	case goPointer:
+		return checks + fmt.Sprintf("\tif v.%s != nil {\n%s\t}\n", field, check), decls
+	case goZero:
+		gen.ImportXSD = true
+		return checks + fmt.Sprintf("\tif !xsd.IsZero(v.%s) {\n%s\t}\n", field, check), decls
+	case goOptional:
+		return checks + fmt.Sprintf("\tif v.%s.Valid {\n%s\t}\n", field, check), decls
+	}
+	return checks + check, decls
+}
+
+// genGoDeclValidate generates the Validate method for the type declared by the
+// top-level element or attribute with the given type. Methods can't be
+// declared on the pointer types, so the declarations which reference complex
+// types are skipped.
+func (gen *CodeGenerator) genGoDeclValidate(typeName, fieldType string, r *Restriction, plural bool) string {
+	if strings.HasPrefix(fieldType, "*") || fieldType == "interface{}" {
+		return ""
+	}
+	value := "v"
+	if plural {
+		value = "item"
+	}
+	var checks, decls string
+	if isGoBuiltInType(fieldType) {
+		checks, decls = gen.genGoFacetChecks(r, fieldType, value, typeName, "pattern"+typeName)
+	} else {
+		if !plural {
+			value = fmt.Sprintf("%s(v)", fieldType)
+		}
+		gen.ImportFmt = true
+		checks = fmt.Sprintf("\tif err := %s.Validate(); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n", value, typeName)
+	}
+	if plural && checks != "" {
+		checks = fmt.Sprintf("\tfor _, item := range v {\n%s\t}\n", checks)
+	}
+	return decls + genGoValueValidate(typeName, checks)
+}
+
+// genGoIdentityConstraints returns the elements of the identity constraints
+// for the runtime check, the scope is the name of the local element which
+// declares them.
+func (gen *CodeGenerator) genGoIdentityConstraints(scope string, constraints []IdentityConstraint) (elements string) {
+	for _, c := range constraints {
+		gen.ImportXSD, gen.ImportFmt = true, true
+		element := fmt.Sprintf("Kind: %q, Name: %q", c.Kind, c.Name)
+		if c.Refer.Local != "" {
+			element += fmt.Sprintf(", Refer: %q", c.Refer.Local)
+		}
+		if scope != "" {
+			element += fmt.Sprintf(", Scope: %q", scope)
+		}
+		var fields []string
+		for _, field := range c.Fields {
+			fields = append(fields, strconv.Quote(field))
+		}
+		elements += fmt.Sprintf("\t\txsd.IdentityConstraint{%s, Selector: %q, Fields: []string{%s}},\n", element, c.Selector, strings.Join(fields, ", "))
+	}
+	return
+}
+
+// genGoElementIdentityValidate generates the validation function for the
+// top-level element with the identity constraints, which is declared as the
+// pointer to the struct of its type, so it can't have the Validate method. The
+// function validates the struct and checks the identity constraints.
+func (gen *CodeGenerator) genGoElementIdentityValidate(typeName string, v *Element) string {
+	fieldType := gen.genGoFieldTypeOf(typeQName(v.TypeName, v.Ref), v.Type)
+	if v.Plural || !strings.HasPrefix(fieldType, "*") {
+		return ""
+	}
+	constraints := gen.genGoIdentityConstraints("", v.IdentityConstraints)
+	if constraints == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n// Validate%s checks the %s element against the facets, the required\n// elements and the identity constraints of the XML schema.\nfunc Validate%s(v %s) error {\n\tif err := (%s)(v).Validate(); err != nil {\n\t\treturn err\n\t}\n\tif err := xsd.CheckIdentityConstraints(v,\n%s\t); err != nil {\n\t\treturn fmt.Errorf(\"%s: %%w\", err)\n\t}\n\treturn nil\n}\n", typeName, v.Name, typeName, typeName, fieldType, constraints, typeName)
+}
+
+// genGoStructValidate generates the Validate method for the generated struct
+// with the given checks.
+func genGoStructValidate(typeName, checks string) string {
+	return fmt.Sprintf("\n// Validate checks the fields of %s against the facets and the required\n// elements of the XML schema, including the nested types.\nfunc (v *%s) Validate() error {\n\tif v == nil {\n\t\treturn nil\n\t}\n%s\treturn nil\n}\n", typeName, typeName, checks)
+}
+
+// genGoValueValidate generates the Validate method for the generated simple
+// type with the given checks.
+func genGoValueValidate(typeName, checks string) string {
+	return fmt.Sprintf("\n// Validate checks the value of %s against the facets of the XML schema.\nfunc (v %s) Validate() error {\n%s\treturn nil\n}\n", typeName, typeName, checks)
+}
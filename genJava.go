@@ -9,9 +9,11 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
-	"os"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -32,38 +34,43 @@ var javaBuildInType = map[string]bool{
 // GenJava generate Java programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenJava() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
 		funcName := fmt.Sprintf("Java%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
-	}
-	f, err := os.Create(gen.FileWithExtension(".java"))
-	if err != nil {
-		return err
+		if err := callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)}); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 	packageName := gen.Package
+	if pkg := gen.currentPackage(); pkg != "" {
+		packageName = genJavaPackage(pkg)
+	}
 	if packageName == "" {
 		packageName = "schema"
 	}
 	importPackage := `import java.util.ArrayList;
 import java.util.List;
+import java.util.Map;
 import javax.xml.bind.annotation.XmlAccessType;
 import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
 import javax.xml.bind.annotation.XmlAttribute;
 import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
 import javax.xml.bind.annotation.XmlSchemaType;
 import javax.xml.bind.annotation.XmlType;
-import javax.xml.bind.annotation.XmlValue;`
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;`
 
-	f.Write([]byte(fmt.Sprintf("%s\n\npackage %s;\n\n%s\n%s", copyright, packageName, importPackage, gen.Field)))
-	return err
+	return gen.writeFile(".java", []byte(fmt.Sprintf("%s\n\npackage %s;\n\n%s\n%s", copyright, packageName, importPackage, gen.Field)))
 }
 
-func genJavaFieldName(name string, unique bool) (fieldName string) {
+func genJavaFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -73,12 +80,6 @@ func genJavaFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
@@ -97,15 +98,76 @@ func genJavaFieldType(name string) string {
 	return "void"
 }
 
+// genJavaPackage returns the Java package name of the package path.
+func genJavaPackage(pkg string) string {
+	return strings.Join(packageIdentifiers(pkg), ".")
+}
+
+// genJavaQualifiedType returns the fully qualified name of the Java class of
+// the schema type with the qualified name, if the namespace of the type is
+// mapped to another package than the generated file. The fully qualified
+// names can't collide with the classes of the same name in the package.
+func (gen *CodeGenerator) genJavaQualifiedType(name xml.Name, fieldType string) string {
+	if pkg := gen.foreignPackage(name); pkg != "" && !isBuiltInJavaType(fieldType) && fieldType != "void" {
+		return genJavaPackage(pkg) + "." + fieldType
+	}
+	return fieldType
+}
+
+// genJavaDefault returns the initializer of the field with the default or
+// fixed value, or an empty string if there is no value or it can't be
+// represented by the literal of the field type.
+func genJavaDefault(fieldType, value string) string {
+	if value == "" {
+		return ""
+	}
+	if fieldType == "String" {
+		return " = " + strconv.Quote(value)
+	}
+	value = strings.TrimSpace(value)
+	var literal string
+	switch fieldType {
+	case "Boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return ""
+		}
+		literal = strconv.FormatBool(b)
+	case "Byte", "Short", "Integer", "Long":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return ""
+		}
+		literal = strconv.FormatInt(n, 10)
+		switch fieldType {
+		case "Byte":
+			literal = "(byte) " + literal
+		case "Short":
+			literal = "(short) " + literal
+		case "Long":
+			literal += "L"
+		}
+	case "Float":
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return ""
+		}
+		literal = strconv.FormatFloat(f, 'g', -1, 32) + "f"
+	default:
+		return ""
+	}
+	return " = " + literal
+}
+
 // JavaSimpleType generates code for simple type XML schema in Java language
 // syntax.
 func (gen *CodeGenerator) JavaSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			content := fmt.Sprintf("\tprotected List<%s> %s;\n", fieldType, genJavaFieldName(v.Name, false))
+			fieldType := genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
+			content := fmt.Sprintf("\tprotected List<%s> %s;\n", fieldType, genJavaFieldName(v.Name))
 			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -117,24 +179,24 @@ func (gen *CodeGenerator) JavaSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
 				fieldType := genJavaFieldType(memberType)
-				content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", fieldType, genJavaFieldName(memberName, false))
+				content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", fieldType, genJavaFieldName(memberName))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
-			fieldName := genJavaFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+			gen.Field += fmt.Sprintf("%spublic class %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		fieldType := genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), v.Name, fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), v.Name, fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -144,55 +206,88 @@ func (gen *CodeGenerator) JavaComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", genJavaFieldType(fieldType), genJavaFieldName(attrGroup.Name, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", gen.genJavaQualifiedType(attrGroup.RefName, genJavaFieldType(fieldType)), genJavaFieldName(attrGroup.Name))
 		}
 
 		for _, attribute := range v.Attributes {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+			if attribute.Wildcard {
+				content += genJavaWildcardField(true, attribute.ProcessContents)
+				continue
+			}
+			fieldType := gen.genJavaQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(attribute.Type))))
 			required := `required = true, `
 			if attribute.Optional {
 				required = ""
 			}
-			content += fmt.Sprintf("\t@XmlAttribute(%sname = \"%s\")\n\tprotected %s %sAttr;\n", required, attribute.Name, fieldType, genJavaFieldName(attribute.Name, false))
+			content += fmt.Sprintf("\t@XmlAttribute(%sname = \"%s\"%s)\n\tprotected %s %sAttr%s;\n", required, trimNSPrefix(attribute.Name), genJavaNamespace(attribute.TargetNamespace), fieldType, genJavaFieldName(attribute.Name), genJavaDefault(fieldType, attribute.Default))
 		}
 		for _, group := range v.Groups {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genJavaQualifiedType(group.RefName, genJavaFieldType(gen.baseType(trimNSPrefix(group.Ref))))
 			if group.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
+			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name))
 		}
 
 		for _, element := range v.Elements {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			if element.Wildcard {
+				content += genJavaWildcardField(false, element.ProcessContents)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genJavaSubstitutionField(&element, members)
+				continue
+			}
+			fieldType := gen.genJavaQualifiedType(typeQName(element.TypeName, element.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(element.Type))))
+			var initializer string
 			if element.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
+			} else {
+				initializer = genJavaDefault(fieldType, element.Default)
 			}
 			required := `required = true, `
 			if element.Optional {
 				required = ""
 			}
-			content += fmt.Sprintf("\t@XmlElement(%sname = \"%s\")\n\tprotected %s %s;\n", required, element.Name, fieldType, genJavaFieldName(element.Name, false))
+			content += fmt.Sprintf("\t@XmlElement(%sname = \"%s\"%s)\n\tprotected %s %s%s;\n", required, trimNSPrefix(element.Name), genJavaNamespace(element.TargetNamespace), fieldType, genJavaFieldName(element.Name), initializer)
 		}
 
 		if len(v.Base) > 0 && isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := genJavaFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			content += fmt.Sprintf("\t@XmlValue\n\tprotected %s value;\n", fieldType)
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
 
 		typeExtension := ""
 		if len(v.Base) > 0 && !isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := gen.genJavaQualifiedType(v.BaseName, genJavaFieldType(gen.baseType(trimNSPrefix(v.Base))))
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%spublic class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		var rootElement string
+		if v.Element {
+			var namespace string
+			if gen.Schema != nil {
+				namespace = gen.Schema.TargetNamespace
+			}
+			rootElement = fmt.Sprintf("@XmlRootElement(name = \"%s\"%s)\n", v.Name, genJavaNamespace(namespace))
+		}
+		gen.Field += fmt.Sprintf("%s%spublic class %s%s%s", genTypeComment(fieldName, v.Doc, v.Assertions, "//"), rootElement, fieldName, typeExtension, gen.StructAST[v.Name])
+	}
+}
+
+// genJavaNamespace returns the namespace argument of the JAXB annotation of
+// the qualified elements and attributes.
+func genJavaNamespace(namespace string) string {
+	if namespace == "" {
+		return ""
 	}
+	return fmt.Sprintf(", namespace = \"%s\"", namespace)
 }
 
 func isBuiltInJavaType(typeName string) bool {
@@ -205,24 +300,36 @@ func (gen *CodeGenerator) JavaGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, element := range v.Elements {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			if element.Wildcard {
+				content += genJavaWildcardField(false, element.ProcessContents)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genJavaSubstitutionField(&element, members)
+				continue
+			}
+			fieldType := gen.genJavaQualifiedType(typeQName(element.TypeName, element.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(element.Type))))
+			var initializer string
 			if element.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
+			} else {
+				initializer = genJavaDefault(fieldType, element.Default)
 			}
-			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\")\n\tprotected %s %s;\n", element.Name, fieldType, genJavaFieldName(element.Name, false))
+			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\"%s)\n\tprotected %s %s%s;\n", trimNSPrefix(element.Name), genJavaNamespace(element.TargetNamespace), fieldType, genJavaFieldName(element.Name), initializer)
 		}
 
 		for _, group := range v.Groups {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genJavaQualifiedType(group.RefName, genJavaFieldType(gen.baseType(trimNSPrefix(group.Ref))))
 			if group.Plural {
 				fieldType = fmt.Sprintf("List<%s>", fieldType)
 			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
+			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name))
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
@@ -233,16 +340,20 @@ func (gen *CodeGenerator) JavaAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += genJavaWildcardField(true, attribute.ProcessContents)
+				continue
+			}
 			required := ", required = true"
 			if attribute.Optional {
 				required = ""
 			}
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
-			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %sAttr %s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false))
+			fieldType := gen.genJavaQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(attribute.Type))))
+			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s%s)\n\tprotected %s %sAttr%s;\n", trimNSPrefix(attribute.Name), genJavaNamespace(attribute.TargetNamespace), required, fieldType, genJavaFieldName(attribute.Name), genJavaDefault(fieldType, attribute.Default))
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genJavaFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genJavaFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
@@ -250,25 +361,54 @@ func (gen *CodeGenerator) JavaAttributeGroup(v *AttributeGroup) {
 // JavaElement generates code for element XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genJavaQualifiedType(typeQName(v.TypeName, v.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(v.Type))))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\"%s)\npublic class %s {\n%s}\n", v.Name, genJavaNamespace(v.TargetNamespace), gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
 	}
 }
 
 // JavaAttribute generates code for attribute XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genJavaQualifiedType(typeQName(v.TypeName, v.Ref), genJavaFieldType(gen.baseType(trimNSPrefix(v.Type))))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
-		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
+		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name))
 		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, gen.uniqueName(genJavaFieldName(v.Name)), gen.StructAST[v.Name])
+	}
+}
+
+// genJavaWildcardField returns the field of the element or attribute
+// wildcard. The elements matched by the lax or skip wildcards are bound to
+// the known classes when possible, and kept as DOM elements otherwise.
+func genJavaWildcardField(attribute bool, processContents string) string {
+	if attribute {
+		return "\t@XmlAnyAttribute\n\tprotected Map<QName, String> AnyAttrs;\n"
+	}
+	var lax string
+	if processContents != "skip" {
+		lax = "(lax = true)"
+	}
+	return fmt.Sprintf("\t@XmlAnyElement%s\n\tprotected List<Object> Any;\n", lax)
+}
+
+// genJavaSubstitutionField returns the field of the element which references
+// the head of a substitution group. The field holds the elements in the
+// substitution group, which are bound to the classes of the members by name.
+func (gen *CodeGenerator) genJavaSubstitutionField(element *Element, members []*Element) string {
+	var elements []string
+	for _, member := range members {
+		elements = append(elements, fmt.Sprintf("\t\t@XmlElement(name = \"%s\", type = %s.class)", trimNSPrefix(member.Name), gen.genJavaQualifiedType(member.TypeName, genJavaFieldType(gen.baseType(trimNSPrefix(member.Type))))))
+	}
+	fieldType := "Object"
+	if element.Plural {
+		fieldType = "List<Object>"
 	}
+	return fmt.Sprintf("\t@XmlElements({\n%s\n\t})\n\tprotected %s %s;\n", strings.Join(elements, ",\n"), fieldType, genJavaFieldName(element.Name))
 }
@@ -9,9 +9,12 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
-	"os"
+	"math"
+	"path"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -96,26 +99,29 @@ var (
 // GenRust generate Go programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenRust() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
 		funcName := fmt.Sprintf("Rust%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
-	}
-	f, err := os.Create(gen.FileWithExtension(".rs"))
-	if err != nil {
-		return err
+		if err := callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)}); err != nil {
+			return err
+		}
 	}
-	defer f.Close()
 	var extern = `use serde::Serialize;
 use serde::Deserialize;
 
 use serde_xml_rs::from_reader;`
+	for _, pkg := range gen.sortedImports() {
+		module := "crate::" + strings.Join(packageIdentifiers(pkg), "::")
+		if alias := gen.imports[pkg]; alias != packageIdentifier(path.Base(pkg)) {
+			module += " as " + alias
+		}
+		extern += fmt.Sprintf("\nuse %s;", module)
+	}
 	source := []byte(fmt.Sprintf("%s\n\n%s\n%s", copyright, extern, gen.Field))
-	f.Write(source)
-	return err
+	return gen.writeFile(".rs", source)
 }
 
 // genRustFieldName generate struct field name for Rust code.
@@ -136,7 +142,7 @@ func genRustFieldName(name string) (fieldName string) {
 }
 
 // genRustStructName generate struct name for Rust code.
-func genRustStructName(name string, unique bool) (structName string) {
+func genRustStructName(name string) (structName string) {
 	for _, str := range strings.Split(name, ":") {
 		structName += MakeFirstUpperCase(str)
 	}
@@ -146,12 +152,6 @@ func genRustStructName(name string, unique bool) (structName string) {
 	}
 	structName = tmp
 	structName = strings.NewReplacer("-", "", "_", "").Replace(structName)
-	if unique {
-		fieldNameCount[structName]++
-		if count := fieldNameCount[structName]; count != 1 {
-			structName = fmt.Sprintf("%s%d", structName, count)
-		}
-	}
 	return
 }
 
@@ -160,23 +160,37 @@ func genRustFieldType(name string) string {
 	if _, ok := rustBuildinType[name]; ok {
 		return name
 	}
-	fieldType := genRustStructName(name, false)
+	fieldType := genRustStructName(name)
 	if fieldType != "" {
 		return fieldType
 	}
 	return "char"
 }
 
+// rustModuleNames are the names used by the generated Rust code, which can't
+// be the aliases of the modules of the namespaces.
+var rustModuleNames = []string{"crate", "self", "serde", "serde_xml_rs", "super"}
+
+// genRustQualifiedType qualifies the Rust field type of the schema type with
+// the qualified name by the path of its module, if the namespace of the type
+// is mapped to another module than the generated file.
+func (gen *CodeGenerator) genRustQualifiedType(name xml.Name, fieldType string) string {
+	if pkg := gen.foreignPackage(name); pkg != "" && !isRustBuiltInType(fieldType) && fieldType != "char" {
+		return gen.packageAlias(pkg, path.Base(pkg), rustModuleNames...) + "::" + fieldType
+	}
+	return fieldType
+}
+
 // RustSimpleType generates code for simple type XML schema in Rust language
 // syntax.
 func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, genRustFieldName(v.Name), fieldType)
 			gen.StructAST[v.Name] = content
-			fieldName := genRustStructName(v.Name, true)
-			gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genRustStructName(v.Name))
+			gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -188,21 +202,21 @@ func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(memberName), genRustFieldType(memberType))
 			}
 			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genRustStructName(v.Name, true), gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", gen.uniqueName(genRustStructName(v.Name)), gen.StructAST[v.Name])
 		}
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		fieldType := genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
 		content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(v.Name), fieldType)
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -210,57 +224,86 @@ func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 // syntax.
 func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		var content string
+		var content, functions string
+		var wildcard bool
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attrGroup.Name, genRustFieldName(attrGroup.Name), genRustFieldType(fieldType))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attrGroup.Name, genRustFieldName(attrGroup.Name), gen.genRustQualifiedType(attrGroup.RefName, genRustFieldType(fieldType)))
 		}
 		for _, attribute := range v.Attributes {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+			if attribute.Wildcard {
+				content += genRustWildcardField(&wildcard)
+				continue
+			}
+			fieldType := gen.genRustQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type))))
+			attr, function := genRustDefault(v.Name, attribute.Name, fieldType, attribute.Default, attribute.Optional)
+			functions += function
 			if attribute.Optional {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), fieldType)
+				content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: Option<%s>,\n", gen.genRustRename(attribute.TargetNamespace, attribute.Name), attr, genRustFieldName(attribute.Name), fieldType)
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", attribute.Name, genRustFieldName(attribute.Name), fieldType)
+				content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: %s,\n", gen.genRustRename(attribute.TargetNamespace, attribute.Name), attr, genRustFieldName(attribute.Name), fieldType)
 			}
 		}
 		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genRustQualifiedType(group.RefName, genRustFieldType(gen.baseType(trimNSPrefix(group.Ref))))
 			fieldName := genRustFieldName(group.Name)
 			if group.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, fieldType)
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, gen.genRustBoxedType(v.Name, trimNSPrefix(group.Ref), fieldType))
 			}
 		}
 		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			if element.Wildcard {
+				content += genRustWildcardField(&wildcard)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genRustSubstitutionField(&element, members, element.Plural)
+				continue
+			}
+			fieldType := gen.genRustQualifiedType(typeQName(element.TypeName, element.Ref), genRustFieldType(gen.baseType(trimNSPrefix(element.Type))))
 			fieldName := genRustFieldName(element.Name)
 			if element.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
+				content += fmt.Sprintf("\t#[serde(%s)]\n\tpub %s: Vec<%s>,\n", gen.genRustRename(element.TargetNamespace, element.Name), fieldName, fieldType)
 			} else {
+				attr, function := genRustDefault(v.Name, element.Name, fieldType, element.Default, element.Optional)
+				functions += function
+				fieldType = gen.genRustBoxedType(v.Name, gen.baseType(trimNSPrefix(element.Type)), fieldType)
 				if element.Optional {
-					content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", element.Name, fieldName, fieldType)
+					content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: Option<%s>,\n", gen.genRustRename(element.TargetNamespace, element.Name), attr, fieldName, fieldType)
 				} else {
-					content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", element.Name, fieldName, fieldType)
+					content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: %s,\n", gen.genRustRename(element.TargetNamespace, element.Name), attr, fieldName, fieldType)
 				}
 			}
 		}
 		if len(v.Base) > 0 {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+			fieldType := genRustFieldType(gen.baseType(trimNSPrefix(v.Base)))
 			if isRustBuiltInType(v.Base) {
 				content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
 			} else {
 				fieldName := genRustFieldName(fieldType)
 				// If the type is not a built-in one, add the base type as a nested field tagged with flatten
-				content += fmt.Sprintf("\t#[serde(flatten)]\n\tpub %s: %s,\n", fieldName, fieldType)
+				content += fmt.Sprintf("\t#[serde(flatten)]\n\tpub %s: %s,\n", fieldName, gen.genRustBoxedType(v.Name, gen.baseType(trimNSPrefix(v.Base)), gen.genRustQualifiedType(v.BaseName, fieldType)))
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n%s", genTypeComment(fieldName, v.Doc, v.Assertions, "//"), fieldName, gen.StructAST[v.Name], functions)
 	}
 }
 
+// genRustBoxedType returns the field type boxed if the named type of the field
+// references the owner type, the recursive structs can't hold the values of
+// each other.
+func (gen *CodeGenerator) genRustBoxedType(owner, name, fieldType string) string {
+	if gen.recursiveType(owner, name) {
+		return fmt.Sprintf("Box<%s>", fieldType)
+	}
+	return fieldType
+}
+
 func isRustBuiltInType(typeName string) bool {
 	_, builtIn := rustBuildinType[typeName]
 	return builtIn
@@ -269,28 +312,39 @@ func isRustBuiltInType(typeName string) bool {
 // RustGroup generates code for group XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		var content string
+		var content, functions string
 		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
+			if element.Wildcard {
+				content += genRustWildcardField(new(bool))
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genRustSubstitutionField(&element, members, v.Plural)
+				continue
+			}
+			fieldType := gen.genRustQualifiedType(typeQName(element.TypeName, element.Ref), genRustFieldType(gen.baseType(trimNSPrefix(element.Type))))
 			fieldName := genRustFieldName(element.Name)
 			if v.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
+				content += fmt.Sprintf("\t#[serde(%s)]\n\tpub %s: Vec<%s>,\n", gen.genRustRename(element.TargetNamespace, element.Name), fieldName, fieldType)
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", element.Name, fieldName, fieldType)
+				attr, function := genRustDefault(v.Name, element.Name, fieldType, element.Default, false)
+				functions += function
+				content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: %s,\n", gen.genRustRename(element.TargetNamespace, element.Name), attr, fieldName, gen.genRustBoxedType(v.Name, gen.baseType(trimNSPrefix(element.Type)), fieldType))
 			}
 		}
 		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
+			fieldType := gen.genRustQualifiedType(group.RefName, genRustFieldType(gen.baseType(trimNSPrefix(group.Ref))))
 			fieldName := genRustFieldName(group.Name)
 			if v.Plural {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, fieldType)
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, gen.genRustBoxedType(v.Name, trimNSPrefix(group.Ref), fieldType))
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name], functions)
 	}
 }
 
@@ -298,24 +352,30 @@ func (gen *CodeGenerator) RustGroup(v *Group) {
 // syntax.
 func (gen *CodeGenerator) RustAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		var content string
+		var content, functions string
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += genRustWildcardField(new(bool))
+				continue
+			}
 			if attribute.Optional {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				attr, function := genRustDefault(v.Name, attribute.Name, gen.genRustQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type)))), attribute.Default, true)
+				functions += function
+				content += fmt.Sprintf("\t#[serde(%s%s)]\n\tpub %s: Option<%s>,\n", gen.genRustRename(attribute.TargetNamespace, attribute.Name), attr, genRustFieldName(attribute.Name), gen.genRustQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type)))))
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				content += fmt.Sprintf("\t#[serde(%s)]\n\tpub %s: Vec<%s>,\n", gen.genRustRename(attribute.TargetNamespace, attribute.Name), genRustFieldName(attribute.Name), gen.genRustQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genRustFieldType(gen.baseType(trimNSPrefix(attribute.Type)))))
 			}
 		}
 		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genRustStructName(v.Name))
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name], functions)
 	}
 }
 
 // RustElement generates code for element XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genRustQualifiedType(typeQName(v.TypeName, v.Ref), genRustFieldType(gen.baseType(trimNSPrefix(v.Type))))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
@@ -329,7 +389,7 @@ func (gen *CodeGenerator) RustElement(v *Element) {
 // RustAttribute generates code for attribute XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+		fieldType := gen.genRustQualifiedType(typeQName(v.TypeName, v.Ref), genRustFieldType(gen.baseType(trimNSPrefix(v.Type))))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
 			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
@@ -339,3 +399,104 @@ func (gen *CodeGenerator) RustAttribute(v *Attribute) {
 		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
+
+// genRustRename returns the arguments of the serde attribute, which renames
+// the field to the local name of the element or attribute. The qualified
+// names are aliased by the prefix declared for the namespace in the schema,
+// for the deserializers which match the prefixed names.
+func (gen *CodeGenerator) genRustRename(namespace, name string) string {
+	rename := fmt.Sprintf("rename = \"%s\"", trimNSPrefix(name))
+	if gen.Schema == nil || namespace == "" {
+		return rename
+	}
+	if prefix := gen.Schema.prefix(namespace); prefix != "" {
+		rename += fmt.Sprintf(", alias = \"%s:%s\"", prefix, trimNSPrefix(name))
+	}
+	return rename
+}
+
+// genRustDefault returns the serde default attribute of the field with the
+// default or fixed value, and the function which returns the value. Nothing
+// is returned if there is no value or it can't be represented by the literal
+// of the field type.
+func genRustDefault(structName, name, fieldType, value string, optional bool) (attr, function string) {
+	if value == "" {
+		return
+	}
+	if fieldType != "String" {
+		value = strings.TrimSpace(value)
+	}
+	var literal string
+	switch fieldType {
+	case "String":
+		literal = strconv.Quote(value) + ".to_string()"
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return
+		}
+		literal = strconv.FormatBool(b)
+	case "i8", "i16", "i32", "i64", "i128", "isize":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return
+		}
+		literal = strconv.FormatInt(n, 10)
+	case "u8", "u16", "u32", "u64", "u128", "usize":
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return
+		}
+		literal = strconv.FormatUint(n, 10)
+	case "f32", "f64":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return
+		}
+		if literal = strconv.FormatFloat(f, 'g', -1, 64); !strings.ContainsAny(literal, ".e") {
+			literal += ".0"
+		}
+	default:
+		return
+	}
+	if optional {
+		fieldType, literal = fmt.Sprintf("Option<%s>", fieldType), fmt.Sprintf("Some(%s)", literal)
+	}
+	funcName := fmt.Sprintf("default_%s_%s", ToSnakeCase(genRustStructName(structName)), strings.TrimSuffix(genRustFieldName(name), "_attr"))
+	return fmt.Sprintf(", default = \"%s\"", funcName), fmt.Sprintf("\nfn %s() -> %s {\n\t%s\n}\n", funcName, fieldType, literal)
+}
+
+// genRustWildcardField returns the field of the element or attribute
+// wildcard, the unknown attributes and elements are collected into a
+// flattened map. Only one map is generated for a struct, since a flattened
+// map takes all the remaining fields.
+func genRustWildcardField(generated *bool) string {
+	if *generated {
+		return ""
+	}
+	*generated = true
+	return "\t#[serde(flatten)]\n\tpub any: std::collections::HashMap<String, String>,\n"
+}
+
+// genRustSubstitutionField returns the field of the element which references
+// the head of a substitution group. The field holds the elements in the
+// substitution group by the generated enum, with a variant for each member.
+func (gen *CodeGenerator) genRustSubstitutionField(element *Element, members []*Element, plural bool) string {
+	enumName := genRustStructName(trimNSPrefix(element.Name)) + "Substitution"
+	if _, ok := gen.StructAST[enumName]; !ok {
+		var content string
+		for _, member := range members {
+			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\t%s(%s),\n", trimNSPrefix(member.Name), genRustStructName(trimNSPrefix(member.Name)), gen.genRustQualifiedType(member.TypeName, genRustFieldType(gen.baseType(trimNSPrefix(member.Type)))))
+		}
+		gen.StructAST[enumName] = content
+		doc := fmt.Sprintf("an element in the substitution group of %s.", trimNSPrefix(element.Name))
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub enum %s {\n%s}\n", genFieldComment(enumName, doc, "//"), enumName, content)
+	}
+	fieldType := enumName
+	if plural {
+		fieldType = fmt.Sprintf("Vec<%s>", enumName)
+	} else if element.Optional {
+		fieldType = fmt.Sprintf("Option<%s>", enumName)
+	}
+	return fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub %s: %s,\n", genRustFieldName(element.Name), fieldType)
+}
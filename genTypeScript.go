@@ -9,9 +9,13 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
-	"os"
+	"math"
+	"path"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -28,25 +32,28 @@ var typeScriptBuildInType = map[string]bool{
 // GenTypeScript generate TypeScript programming language source code for XML
 // schema definition files.
 func (gen *CodeGenerator) GenTypeScript() error {
-	fieldNameCount = make(map[string]int)
+	gen.fieldNameCount = make(map[string]int)
 	for _, ele := range gen.ProtoTree {
 		if ele == nil {
 			continue
 		}
 		funcName := fmt.Sprintf("TypeScript%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+		if err := callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)}); err != nil {
+			return err
+		}
+	}
+	var imports string
+	for _, file := range gen.sortedImports() {
+		imports += fmt.Sprintf("import * as %s from %q;\n", gen.imports[file], gen.genTypeScriptModule(file))
 	}
-	f, err := os.Create(gen.FileWithExtension(".ts"))
-	if err != nil {
-		return err
+	if imports != "" {
+		imports = "\n" + imports
 	}
-	defer f.Close()
-	source := []byte(fmt.Sprintf("%s\n%s", copyright, gen.Field))
-	f.Write(source)
-	return err
+	source := []byte(fmt.Sprintf("%s\n%s%s", copyright, imports, gen.Field))
+	return gen.writeFile(".ts", source)
 }
 
-func genTypeScriptFieldName(name string, unique bool) (fieldName string) {
+func genTypeScriptFieldName(name string) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
 	}
@@ -56,12 +63,6 @@ func genTypeScriptFieldName(name string, unique bool) (fieldName string) {
 	}
 	fieldName = tmp
 	fieldName = strings.Replace(fieldName, "-", "", -1)
-	if unique {
-		fieldNameCount[fieldName]++
-		if count := fieldNameCount[fieldName]; count != 1 {
-			fieldName = fmt.Sprintf("%s%d", fieldName, count)
-		}
-	}
 	return
 }
 
@@ -83,16 +84,53 @@ func genTypeScriptFieldType(name string, plural bool) (fieldType string) {
 	return
 }
 
+// genTypeScriptQualifiedType qualifies the TypeScript field type of the schema
+// type with the qualified name by the alias of the module which declares it,
+// if the namespace of the type is mapped to another module than the generated
+// file.
+func (gen *CodeGenerator) genTypeScriptQualifiedType(name xml.Name, fieldType string) string {
+	local := fieldType
+	if strings.HasPrefix(fieldType, "Array<") {
+		local = strings.TrimSuffix(strings.TrimPrefix(fieldType, "Array<"), ">")
+	}
+	pkg := gen.foreignPackage(name)
+	if _, ok := typeScriptBuildInType[local]; ok || pkg == "" || local == "any" {
+		return fieldType
+	}
+	qualified := gen.packageAlias(gen.schemaFile(name), path.Base(pkg), "xml") + "." + local
+	if local != fieldType {
+		return fmt.Sprintf("Array<%s>", qualified)
+	}
+	return qualified
+}
+
+// genTypeScriptModule returns the module specifier of the generated file,
+// which is relative to the directory of the file being generated.
+func (gen *CodeGenerator) genTypeScriptModule(file string) string {
+	dir := "."
+	if current, err := filepath.Rel(gen.outputDir, gen.File); err == nil {
+		dir = path.Dir(filepath.ToSlash(current))
+	}
+	module := file
+	if rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(file)); err == nil {
+		module = filepath.ToSlash(rel)
+	}
+	if !strings.HasPrefix(module, ".") {
+		module = "./" + module
+	}
+	return module
+}
+
 // TypeScriptSimpleType generates code for simple type XML schema in TypeScript language
 // syntax.
 func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 	if v.List {
 		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), true)
+			fieldType := genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), true)
 			content := fmt.Sprintf(" = %s;\n", fieldType)
 			gen.StructAST[v.Name] = content
-			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport type %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+			gen.Field += fmt.Sprintf("%sexport type %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 			return
 		}
 	}
@@ -104,20 +142,20 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 				memberType := member.value
 
 				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
+					memberType = gen.baseType(memberName)
 				}
-				content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(memberName, false), genTypeScriptFieldType(memberType, false))
+				content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(memberName), genTypeScriptFieldType(memberType, false))
 			}
 			content += "}\n"
 			gen.StructAST[v.Name] = content
-			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+			gen.Field += fmt.Sprintf("%sexport class %s%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 		}
 		return
 	}
 	if len(v.Restriction.Enum) > 0 {
 		var content string
-		baseType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		baseType := genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false)
 		for _, enum := range v.Restriction.Enum {
 			switch baseType {
 			case "string":
@@ -128,15 +166,15 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 				content += fmt.Sprintf("\tEnum%s = '%s',\n", enum, enum)
 			}
 		}
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport enum %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, content)
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%sexport enum %s {\n%s}\n", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, content)
 		return
 	}
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false))
+		content := fmt.Sprintf(" %s;\n", genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false))
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%sexport type %s =%s", genTypeComment(fieldName, v.Doc, v.Restriction.Assertions, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
 
@@ -145,29 +183,45 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 func (gen *CodeGenerator) TypeScriptComplexType(v *ComplexType) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
+		var defaults string
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(attrGroup.Name, false), genTypeScriptFieldType(fieldType, false))
+			fieldType := gen.baseType(trimNSPrefix(attrGroup.Ref))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(attrGroup.Name), gen.genTypeScriptQualifiedType(attrGroup.RefName, genTypeScriptFieldType(fieldType, false)))
 		}
 
 		for _, attribute := range v.Attributes {
-			fieldType := genTypeScriptFieldType(
-				getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree),
-				attribute.Plural,
+			if attribute.Wildcard {
+				content += genTypeScriptWildcardField(true, true)
+				continue
+			}
+			fieldType := gen.genTypeScriptQualifiedType(
+				typeQName(attribute.TypeName, attribute.Ref),
+				genTypeScriptFieldType(gen.baseType(trimNSPrefix(attribute.Type)), attribute.Plural),
 			)
-			fieldName := genTypeScriptFieldName(attribute.Name, false) + "Attr"
+			fieldName := genTypeScriptFieldName(attribute.Name) + "Attr"
+			defaults += genTypeScriptDefault(fieldName, fieldType, attribute.Default)
 			if attribute.Optional {
 				fieldName += "?"
 			}
 			content += fmt.Sprintf("\t%s: %s;\n", fieldName, fieldType)
 		}
 		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name), gen.genTypeScriptQualifiedType(group.RefName, genTypeScriptFieldType(gen.baseType(trimNSPrefix(group.Ref)), group.Plural)))
 		}
 
 		for _, element := range v.Elements {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural)
-			fieldName := genTypeScriptFieldName(element.Name, false)
+			if element.Wildcard {
+				content += genTypeScriptWildcardField(false, element.Optional)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genTypeScriptSubstitutionField(&element, members)
+				continue
+			}
+			fieldType := gen.genTypeScriptQualifiedType(typeQName(element.TypeName, element.Ref), genTypeScriptFieldType(gen.baseType(trimNSPrefix(element.Type)), element.Plural))
+			fieldName := genTypeScriptFieldName(element.Name)
+			defaults += genTypeScriptDefault(fieldName, fieldType, element.Default)
 			if element.Optional {
 				fieldName += `?`
 			}
@@ -175,20 +229,20 @@ func (gen *CodeGenerator) TypeScriptComplexType(v *ComplexType) {
 		}
 
 		if len(v.Base) > 0 && isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+			fieldType := genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false)
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
 		typeExtension := ""
 		if len(v.Base) > 0 && !isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+			fieldType := gen.genTypeScriptQualifiedType(v.BaseName, genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Base)), false))
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport class %s%s%s%s", genTypeComment(fieldName, v.Doc, v.Assertions, "//"), fieldName, typeExtension, gen.StructAST[v.Name], genTypeScriptDefaults(fieldName, defaults))
 	}
 }
 
@@ -201,18 +255,30 @@ func isBuiltInTypeScriptType(typeName string) bool {
 func (gen *CodeGenerator) TypeScriptGroup(v *Group) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
+		var defaults string
 		for _, element := range v.Elements {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural))
+			if element.Wildcard {
+				content += genTypeScriptWildcardField(false, element.Optional)
+				continue
+			}
+			content += gen.genAlternativeComment(&element, "//")
+			if members := gen.substitutionGroup(&element); members != nil {
+				content += gen.genTypeScriptSubstitutionField(&element, members)
+				continue
+			}
+			fieldType := gen.genTypeScriptQualifiedType(typeQName(element.TypeName, element.Ref), genTypeScriptFieldType(gen.baseType(trimNSPrefix(element.Type)), element.Plural))
+			defaults += genTypeScriptDefault(genTypeScriptFieldName(element.Name), fieldType, element.Default)
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name), fieldType)
 		}
 
 		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
+			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name), gen.genTypeScriptQualifiedType(group.RefName, genTypeScriptFieldType(gen.baseType(trimNSPrefix(group.Ref)), group.Plural)))
 		}
 
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name], genTypeScriptDefaults(fieldName, defaults))
 	}
 }
 
@@ -221,25 +287,32 @@ func (gen *CodeGenerator) TypeScriptGroup(v *Group) {
 func (gen *CodeGenerator) TypeScriptAttributeGroup(v *AttributeGroup) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
 		content := " {\n"
+		var defaults string
 		for _, attribute := range v.Attributes {
+			if attribute.Wildcard {
+				content += genTypeScriptWildcardField(true, true)
+				continue
+			}
 			var optional string
 			if attribute.Optional {
 				optional = ` | null`
 			}
-			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree), attribute.Plural), optional)
+			fieldType := gen.genTypeScriptQualifiedType(typeQName(attribute.TypeName, attribute.Ref), genTypeScriptFieldType(gen.baseType(trimNSPrefix(attribute.Type)), attribute.Plural))
+			defaults += genTypeScriptDefault(genTypeScriptFieldName(attribute.Name)+"Attr", fieldType, attribute.Default)
+			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name), fieldType, optional)
 		}
 		content += "}\n"
 		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
+		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name], genTypeScriptDefaults(fieldName, defaults))
 	}
 }
 
 // TypeScriptElement generates code for element XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptElement(v *Element) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
-		fieldName := genTypeScriptFieldName(v.Name, true)
+		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", gen.genTypeScriptQualifiedType(typeQName(v.TypeName, v.Ref), genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Type)), v.Plural)))
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
@@ -247,8 +320,89 @@ func (gen *CodeGenerator) TypeScriptElement(v *Element) {
 // TypeScriptAttribute generates code for attribute XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptAttribute(v *Attribute) {
 	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
-		fieldName := genTypeScriptFieldName(v.Name, true)
+		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", gen.genTypeScriptQualifiedType(typeQName(v.TypeName, v.Ref), genTypeScriptFieldType(gen.baseType(trimNSPrefix(v.Type)), v.Plural)))
+		fieldName := gen.uniqueName(genTypeScriptFieldName(v.Name))
 		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
 }
+
+// genTypeScriptDefault returns the property of the default values object,
+// which holds the default or fixed value of the field. An empty string is
+// returned if there is no value or it can't be represented by the literal of
+// the field type.
+func genTypeScriptDefault(fieldName, fieldType, value string) string {
+	if value == "" {
+		return ""
+	}
+	var literal string
+	switch fieldType {
+	case "string":
+		literal = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`).Replace(value) + "'"
+	case "boolean":
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return ""
+		}
+		literal = strconv.FormatBool(b)
+	case "number":
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return ""
+		}
+		literal = strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return ""
+	}
+	return fmt.Sprintf("\t%s: %s,\n", fieldName, literal)
+}
+
+// genTypeScriptDefaults returns the object which holds the default and fixed
+// values of the class with the given properties, or an empty string if there
+// are no properties.
+func genTypeScriptDefaults(typeName, properties string) string {
+	if properties == "" {
+		return ""
+	}
+	return fmt.Sprintf("\nexport const %sDefaults: Partial<%s> = {\n%s};\n", typeName, typeName, properties)
+}
+
+// genTypeScriptWildcardField returns the field of the element or attribute
+// wildcard. The matched elements are kept as a list of the parsed values, and
+// the matched attributes, which are always optional, as the values by the
+// attribute names.
+func genTypeScriptWildcardField(attribute, optional bool) string {
+	if attribute {
+		return "\tAnyAttrs?: { [name: string]: string };\n"
+	}
+	fieldName := "Any"
+	if optional {
+		fieldName += "?"
+	}
+	return fmt.Sprintf("\t%s: Array<any>;\n", fieldName)
+}
+
+// genTypeScriptSubstitutionField returns the field of the element which
+// references the head of a substitution group. The field holds the elements
+// in the substitution group by the generated union of the member types.
+func (gen *CodeGenerator) genTypeScriptSubstitutionField(element *Element, members []*Element) string {
+	typeName := genTypeScriptFieldType(trimNSPrefix(element.Name), false) + "Substitution"
+	if _, ok := gen.StructAST[typeName]; !ok {
+		var types []string
+		seen := make(map[string]bool)
+		for _, member := range members {
+			fieldType := gen.genTypeScriptQualifiedType(member.TypeName, genTypeScriptFieldType(gen.baseType(trimNSPrefix(member.Type)), false))
+			if !seen[fieldType] {
+				seen[fieldType] = true
+				types = append(types, fieldType)
+			}
+		}
+		gen.StructAST[typeName] = strings.Join(types, " | ")
+		doc := fmt.Sprintf("an element in the substitution group of %s.", trimNSPrefix(element.Name))
+		gen.Field += fmt.Sprintf("%sexport type %s = %s;\n", genFieldComment(typeName, doc, "//"), typeName, gen.StructAST[typeName])
+	}
+	fieldName := genTypeScriptFieldName(element.Name)
+	if element.Optional {
+		fieldName += "?"
+	}
+	return fmt.Sprintf("\t%s: %s;\n", fieldName, genTypeScriptFieldType(typeName, element.Plural))
+}
@@ -62,3 +62,28 @@ type Hook interface {
 	// and can be modified directly.
 	OnAddContent(gen *CodeGenerator, content *string)
 }
+
+// AssertionHook is an optional extension of the Hook, which is called with
+// the assertions and type alternatives of XSD 1.1 before they are added to
+// the model. The Hook set in the Options may implement it to inspect or
+// rewrite the XPath test expressions, which are not evaluated by the parser.
+//
+// Example:
+//
+//	func (h *CustomHook) OnAssertion(opt *Options, v *Assertion) (next bool, err error) {
+//	    v.Test = strings.TrimSpace(v.Test)
+//	    return true, nil
+//	}
+//
+// For a complete working example, see TestParseAssertionHook in parser_test.go.
+type AssertionHook interface {
+	// OnAssertion is called with the assert element of the complex types, and
+	// the assertion facet of the simple types. Return next=false to drop the
+	// assertion. Return an error to halt parsing.
+	OnAssertion(opt *Options, v *Assertion) (next bool, err error)
+
+	// OnAlternative is called with the alternative element of the element
+	// declarations. Return next=false to drop the alternative. Return an
+	// error to halt parsing.
+	OnAlternative(opt *Options, v *Alternative) (next bool, err error)
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Output creates the destinations of the generated source code files. The
+// name is the path of the file that would be written to the output directory,
+// with the extension of the language. The generated code is written to the
+// operating system file system when no output is specified in the options.
+type Output interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// OutputFunc is an adapter to allow the use of an ordinary function, such as
+// a factory of io.Writer, as the output of the generated code.
+type OutputFunc func(name string) (io.WriteCloser, error)
+
+// Create calls f(name).
+func (f OutputFunc) Create(name string) (io.WriteCloser, error) {
+	return f(name)
+}
+
+// MemoryOutput keeps the generated source code files in memory, which is
+// useful for generating code without touching the disk, or comparing the
+// generated code with the existing files. It is safe for concurrent use.
+type MemoryOutput struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemoryOutput creates an empty in memory output.
+func NewMemoryOutput() *MemoryOutput {
+	return &MemoryOutput{files: make(map[string][]byte)}
+}
+
+// Create returns a writer for the file with the given name, the content is
+// stored when the writer is closed.
+func (o *MemoryOutput) Create(name string) (io.WriteCloser, error) {
+	return &memoryFile{name: name, output: o}, nil
+}
+
+// Files returns a copy of the generated files by name.
+func (o *MemoryOutput) Files() map[string][]byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	files := make(map[string][]byte, len(o.files))
+	for name, content := range o.files {
+		files[name] = content
+	}
+	return files
+}
+
+// File returns the content of the generated file with the given name.
+func (o *MemoryOutput) File(name string) ([]byte, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	content, ok := o.files[name]
+	return content, ok
+}
+
+// Names returns the sorted names of the generated files.
+func (o *MemoryOutput) Names() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	names := make([]string, 0, len(o.files))
+	for name := range o.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// memoryFile buffers the content of a generated file of the MemoryOutput.
+type memoryFile struct {
+	bytes.Buffer
+	name   string
+	output *MemoryOutput
+}
+
+// Close stores the buffered content in the output.
+func (f *memoryFile) Close() error {
+	f.output.mu.Lock()
+	defer f.output.mu.Unlock()
+	f.output.files[f.name] = f.Bytes()
+	return nil
+}
+
+// writeFile writes the generated source code file with the given extension
+// to the output of the code generator. The file is recorded in the schema
+// model, so that it could be written again for the parsers which get the
+// schema from the schema cache.
+func (gen *CodeGenerator) writeFile(extension string, source []byte) error {
+	name := gen.FileWithExtension(extension)
+	if err := writeOutput(gen.Output, name, source); err != nil {
+		return err
+	}
+	if gen.Schema != nil {
+		if gen.Schema.generated == nil {
+			gen.Schema.generated = make(map[string][]byte)
+		}
+		gen.Schema.generated[name] = source
+	}
+	return nil
+}
+
+// writeOutput writes the source code file with the given name to the output,
+// or to the operating system file system if the output is nil.
+func writeOutput(output Output, name string, source []byte) (err error) {
+	var f io.WriteCloser
+	if output != nil {
+		f, err = output.Create(name)
+	} else {
+		f, err = os.Create(name)
+	}
+	if err != nil {
+		return &OutputError{Path: name, Err: err}
+	}
+	if _, err = f.Write(source); err != nil {
+		f.Close()
+		return &OutputError{Path: name, Err: err}
+	}
+	if err = f.Close(); err != nil {
+		return &OutputError{Path: name, Err: err}
+	}
+	return nil
+}
+
+// writeCached writes the files generated for the cached schema model and the
+// schemas it includes or imports to the output of the options. The files are
+// only written to the outputs specified in the options, the files in the
+// output directory have been written by the parser which cached the schema.
+func (opt *Options) writeCached(schema *Schema) error {
+	if opt.Output == nil {
+		return nil
+	}
+	names := make([]string, 0, len(schema.generated))
+	for name := range schema.generated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeOutput(opt.Output, name, schema.generated[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addGenerated records the files generated for the included or imported
+// schema in the schema model of the options.
+func (opt *Options) addGenerated(schema *Schema) {
+	if len(schema.generated) == 0 || opt.Schema == nil {
+		return
+	}
+	if opt.Schema.generated == nil {
+		opt.Schema.generated = make(map[string][]byte)
+	}
+	for name, source := range schema.generated {
+		opt.Schema.generated[name] = source
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// packagePath returns the package path which the namespace is mapped to by
+// the NamespacePackages option, or an empty string for the namespaces
+// without one.
+func (gen *CodeGenerator) packagePath(namespace string) string {
+	return strings.Trim(gen.NamespacePackages[namespace], "/")
+}
+
+// currentPackage returns the package path of the generated file, which is
+// the one mapped to the target namespace of the schema.
+func (gen *CodeGenerator) currentPackage() string {
+	if gen.Schema == nil {
+		return ""
+	}
+	return gen.packagePath(gen.Schema.TargetNamespace)
+}
+
+// foreignPackage returns the package path of the schema type with the
+// qualified name, if it's generated into another package than the current
+// file. It returns an empty string for the types in the current package.
+func (gen *CodeGenerator) foreignPackage(name xml.Name) string {
+	if name.Space == "" {
+		return ""
+	}
+	if pkg := gen.packagePath(name.Space); pkg != gen.currentPackage() {
+		return pkg
+	}
+	return ""
+}
+
+// typeQName returns the qualified name of the type of the element or
+// attribute, which is the referenced declaration without the type attribute.
+func typeQName(typeName, ref xml.Name) xml.Name {
+	if typeName.Local == "" {
+		return ref
+	}
+	return typeName
+}
+
+// packageAlias returns the name which the package is referenced by in the
+// generated file, and adds the package to the imports of it. The alias is
+// the identifier of the given name, or of the whole package path if it's
+// taken by another package or by the reserved names of the language.
+func (gen *CodeGenerator) packageAlias(pkg, name string, reserved ...string) string {
+	if alias, ok := gen.imports[pkg]; ok {
+		return alias
+	}
+	if gen.imports == nil {
+		gen.imports = make(map[string]string)
+	}
+	taken := make(map[string]bool)
+	for _, name := range reserved {
+		taken[name] = true
+	}
+	for _, alias := range gen.imports {
+		taken[alias] = true
+	}
+	alias := packageIdentifier(name)
+	if taken[alias] {
+		alias = packageIdentifier(pkg)
+	}
+	gen.imports[pkg] = alias
+	return alias
+}
+
+// sortedImports returns the package paths imported by the generated file in
+// the lexical order.
+func (gen *CodeGenerator) sortedImports() []string {
+	packages := make([]string, 0, len(gen.imports))
+	for pkg := range gen.imports {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
+
+// packageIdentifier returns the identifier of the package path, which
+// replaces the characters other than letters and digits by underscores.
+func packageIdentifier(pkg string) string {
+	identifier := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, pkg)
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "_" + identifier
+	}
+	return identifier
+}
+
+// packageIdentifiers returns the identifiers of the elements of the package
+// path.
+func packageIdentifiers(pkg string) (identifiers []string) {
+	for _, name := range strings.Split(pkg, "/") {
+		identifiers = append(identifiers, packageIdentifier(name))
+	}
+	return
+}
+
+// schemaFile returns the path of the generated file of the schema which
+// declares the type with the qualified name, relative to the output
+// directory and without the extension of the language. It returns the
+// package path if the schema isn't linked to the current one.
+func (gen *CodeGenerator) schemaFile(name xml.Name) string {
+	pkg := gen.packagePath(name.Space)
+	if gen.Schema == nil {
+		return pkg
+	}
+	var file string
+	gen.Schema.walk(func(s *Schema) bool {
+		if s.TargetNamespace != name.Space {
+			return false
+		}
+		if s.SimpleTypes[name] != nil || s.ComplexTypes[name] != nil || s.Elements[name] != nil || s.Attributes[name] != nil {
+			file = path.Join(pkg, path.Base(filepath.ToSlash(s.FilePath)))
+			return true
+		}
+		return false
+	}, map[*Schema]bool{})
+	if file == "" {
+		return pkg
+	}
+	return file
+}
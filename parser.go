@@ -9,11 +9,17 @@
 package xgen
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"golang.org/x/net/html/charset"
@@ -29,6 +35,16 @@ type Options struct {
 	Extract             bool
 	Lang                string
 	Package             string
+	Validation          bool
+	XSDTimeTypes        bool
+	Optionality         string
+	FS                  fs.FS
+	Output              Output
+	Fetcher             Fetcher
+	Catalogs            []string
+	Schema              *Schema
+	SchemaMap           map[string]*Schema
+	Cache               *SchemaCache
 	IncludeMap          map[string]bool
 	LocalNameNSMap      map[string]string
 	NSSchemaLocationMap map[string]string
@@ -37,6 +53,8 @@ type Options struct {
 	ProtoTree           []interface{}
 	RemoteSchema        map[string][]byte
 	Hook                Hook
+	NamespacePackages   map[string]string
+	ImportPath          string
 
 	InElement        string
 	CurrentEle       string
@@ -45,13 +63,22 @@ type Options struct {
 	InAttributeGroup bool
 	InPluralSequence []bool
 
-	SimpleType     *Stack
-	ComplexType    *Stack
-	Element        *Stack
-	Attribute      *Stack
-	Group          *Stack
-	AttributeGroup *Stack
-	Choice         *Stack
+	SimpleType         *Stack
+	ComplexType        *Stack
+	Element            *Stack
+	Attribute          *Stack
+	Group              *Stack
+	AttributeGroup     *Stack
+	Choice             *Stack
+	IdentityConstraint *Stack
+
+	catalog             *catalog
+	alternatives        []Alternative
+	identityConstraints []IdentityConstraint
+	symbols             *symbolTable
+	typeNames           map[string]bool
+	patternStep         bool
+	extractFileMap      map[string]*Schema
 }
 
 // NewParser creates a new parser options for the Parse. Useful for XML schema
@@ -62,28 +89,136 @@ func NewParser(options *Options) *Options {
 
 // Parse reads XML documents and return proto tree for every element in the
 // documents by given options. If value of the property extract is false,
-// parse will fetch schema used in <import> or <include> statements.
+// parse will fetch schema used in <import> or <include> statements. The file
+// path could be an http(s) URL, which is fetched by the fetcher of the
+// options.
 func (opt *Options) Parse() (err error) {
-	opt.FileDir = filepath.Dir(opt.FilePath)
-	var fi os.FileInfo
-	fi, err = os.Stat(opt.FilePath)
+	if isValidURL(opt.FilePath) {
+		var body []byte
+		if body, err = opt.fetch(opt.FilePath); err != nil {
+			return
+		}
+		return opt.parse(bytes.NewReader(body))
+	}
+	opt.FileDir = opt.dirPath(opt.FilePath)
+	var fi fs.FileInfo
+	fi, err = opt.statFile(opt.FilePath)
 	if err != nil {
 		return
 	}
 	if fi.IsDir() {
 		return
 	}
-	var xmlFile *os.File
-	xmlFile, err = os.Open(opt.FilePath)
+	var xmlFile io.ReadCloser
+	xmlFile, err = opt.openFile(opt.FilePath)
 	if err != nil {
 		return
 	}
 	defer xmlFile.Close()
+	return opt.parse(xmlFile)
+}
+
+// ParseReader reads the XML schema document with the given name from r and
+// returns proto tree by given options. The name is used as the file path of
+// the document, the schemas in <import> or <include> statements are resolved
+// relative to it from the FS of the options, or from the operating system
+// file system if the FS is nil. Use bytes.NewReader to parse the document in
+// a byte slice.
+func (opt *Options) ParseReader(name string, r io.Reader) error {
+	opt.FilePath = name
+	opt.FileDir = opt.dirPath(name)
+	if opt.InputDir == "" {
+		opt.InputDir = opt.FileDir
+	}
+	return opt.parse(r)
+}
+
+// ParseFS reads the XML schema documents from the file system fsys by given
+// options, the schemas in <import> or <include> statements are resolved in
+// the same file system. If root is a directory, each XML schema definition
+// file in the directory tree is parsed with its own parsing state, and code
+// is generated for each of them.
+func (opt *Options) ParseFS(fsys fs.FS, root string) error {
+	opt.FS = fsys
+	fi, err := fs.Stat(fsys, root)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		opt.FilePath = root
+		if opt.InputDir == "" {
+			opt.InputDir = path.Dir(root)
+		}
+		return opt.Parse()
+	}
+	if opt.InputDir == "" {
+		opt.InputDir = root
+	}
+	return fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != ".xsd" {
+			return err
+		}
+		return NewParser(&Options{
+			FilePath:          name,
+			InputDir:          opt.InputDir,
+			OutputDir:         opt.OutputDir,
+			Lang:              opt.Lang,
+			Package:           opt.Package,
+			Validation:        opt.Validation,
+			XSDTimeTypes:      opt.XSDTimeTypes,
+			Optionality:       opt.Optionality,
+			FS:                opt.FS,
+			Output:            opt.Output,
+			Fetcher:           opt.Fetcher,
+			Catalogs:          opt.Catalogs,
+			Cache:             opt.Cache,
+			Hook:              opt.Hook,
+			NamespacePackages: opt.NamespacePackages,
+			ImportPath:        opt.ImportPath,
+		}).Parse()
+	})
+}
+
+// parse reads the XML schema document from r and return proto tree for every
+// element in the document.
+func (opt *Options) parse(r io.Reader) (err error) {
+	if err = opt.loadCatalogs(); err != nil {
+		return
+	}
+	if opt.IncludeMap == nil {
+		opt.IncludeMap = make(map[string]bool)
+	}
+	if opt.LocalNameNSMap == nil {
+		opt.LocalNameNSMap = make(map[string]string)
+	}
+	if opt.NSSchemaLocationMap == nil {
+		opt.NSSchemaLocationMap = make(map[string]string)
+	}
+	if opt.ParseFileList == nil {
+		opt.ParseFileList = make(map[string]bool)
+	}
+	if opt.ParseFileMap == nil {
+		opt.ParseFileMap = make(map[string][]interface{})
+	}
+	if opt.RemoteSchema == nil {
+		opt.RemoteSchema = make(map[string][]byte)
+	}
+	if opt.SchemaMap == nil {
+		opt.SchemaMap = make(map[string]*Schema)
+	}
+	if opt.extractFileMap == nil {
+		opt.extractFileMap = make(map[string]*Schema)
+	}
+	// the schema model is registered before parsing, so that the schemas
+	// which reference each other could be linked
+	opt.Schema = newSchema(opt.FilePath)
+	opt.SchemaMap[opt.FilePath] = opt.Schema
 	if !opt.Extract {
 		opt.ParseFileList[opt.FilePath] = true
 		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
 	}
 	opt.ProtoTree = make([]interface{}, 0)
+	opt.symbols = &symbolTable{}
 
 	opt.InElement = ""
 	opt.CurrentEle = ""
@@ -98,14 +233,30 @@ func (opt *Options) Parse() (err error) {
 	opt.Group = NewStack()
 	opt.AttributeGroup = NewStack()
 	opt.Choice = NewStack()
+	opt.IdentityConstraint = NewStack()
+	opt.alternatives = nil
+	opt.identityConstraints = nil
 
-	decoder := xml.NewDecoder(xmlFile)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return opt.schemaError(0, 0, "", err)
+	}
+	opt.typeNames = declaredTypeNames(data)
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
 	decoder.CharsetReader = charset.NewReaderLabel
 	for {
-		token, _ := decoder.Token()
-		if token == nil {
+		// the position of the token start, which is reported for the errors
+		// in processing the token
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			line, column = decoder.InputPos()
+			return opt.schemaError(line, column, "", err)
+		}
 
 		next := true
 		switch element := token.(type) {
@@ -113,7 +264,7 @@ func (opt *Options) Parse() (err error) {
 			if opt.Hook != nil {
 				next, err = opt.Hook.OnStartElement(opt, element, opt.ProtoTree)
 				if err != nil {
-					return err
+					return opt.schemaError(line, column, element.Name.Local, err)
 				}
 
 				// skip to next element/token
@@ -125,13 +276,13 @@ func (opt *Options) Parse() (err error) {
 			opt.InElement = element.Name.Local
 			funcName := fmt.Sprintf("On%s", MakeFirstUpperCase(opt.InElement))
 			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
+				return opt.schemaError(line, column, element.Name.Local, err)
 			}
 		case xml.EndElement:
 			if opt.Hook != nil {
 				next, err = opt.Hook.OnEndElement(opt, element, opt.ProtoTree)
 				if err != nil {
-					return err
+					return opt.schemaError(line, column, element.Name.Local, err)
 				}
 
 				// skip to next element/token
@@ -142,13 +293,13 @@ func (opt *Options) Parse() (err error) {
 
 			funcName := fmt.Sprintf("End%s", MakeFirstUpperCase(element.Name.Local))
 			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
+				return opt.schemaError(line, column, element.Name.Local, err)
 			}
 		case xml.CharData:
 			if opt.Hook != nil {
 				next, err = opt.Hook.OnCharData(opt, string(element), opt.ProtoTree)
 				if err != nil {
-					return err
+					return opt.schemaError(line, column, opt.InElement, err)
 				}
 
 				// skip to next element/token
@@ -158,81 +309,112 @@ func (opt *Options) Parse() (err error) {
 			}
 
 			if err = opt.OnCharData(string(element), opt.ProtoTree); err != nil {
-				return
+				return opt.schemaError(line, column, opt.InElement, err)
 			}
 		default:
 		}
 	}
 
+	opt.Schema.index(opt.ProtoTree)
+	// the proto tree is complete, and the symbol table of it isn't updated
+	// any more, so it could be shared with the schema
+	opt.Schema.symbols = opt.symbols.update(opt.ProtoTree)
+	if err = opt.linkSchemas(); err != nil {
+		return
+	}
+	opt.resolveRedefinitions()
+	opt.resolveDerivations()
+	opt.Schema.resolve(opt.ProtoTree)
 	if !opt.Extract {
 		opt.ParseFileList[opt.FilePath] = true
 		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
-		path := filepath.Join(opt.OutputDir, strings.TrimPrefix(opt.FilePath, opt.InputDir))
-		if err := PrepareOutputDir(filepath.Dir(path)); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		filePath := opt.outputPath()
+		if opt.Output == nil {
+			if err = PrepareOutputDir(filepath.Dir(filePath)); err != nil {
+				return &OutputError{Path: filepath.Dir(filePath), Err: err}
+			}
 		}
 		generator := &CodeGenerator{
-			Lang:      opt.Lang,
-			Package:   opt.Package,
-			File:      path,
-			ProtoTree: opt.ProtoTree,
-			StructAST: map[string]string{},
-			Hook:      opt.Hook,
+			Lang:              opt.Lang,
+			Package:           opt.Package,
+			Validation:        opt.Validation,
+			Optionality:       opt.Optionality,
+			Output:            opt.Output,
+			File:              filePath,
+			ProtoTree:         opt.ProtoTree,
+			Schema:            opt.Schema,
+			StructAST:         map[string]string{},
+			Hook:              opt.Hook,
+			NamespacePackages: opt.NamespacePackages,
+			ImportPath:        opt.ImportPath,
+			outputDir:         opt.OutputDir,
 		}
 		funcName := fmt.Sprintf("Gen%s", MakeFirstUpperCase(opt.Lang))
 		if err = callFuncByName(generator, funcName, []reflect.Value{}); err != nil {
 			return
 		}
 	}
+	opt.Cache.store(opt.schemaCacheKey(opt.FilePath, opt.Extract), opt.Schema)
 	return
 }
 
+// getFieldValueType returns the value type of the element or attribute which
+// references the given type. Unlike GetValueType, references to simple types
+// with constraining facets keep the type name, so that generators can use the
+// generated enumeration type or validation method for the field.
+func (opt *Options) getFieldValueType(value string, XSDSchema []interface{}) (string, error) {
+	if v := opt.simpleType(trimNSPrefix(value), XSDSchema); v != nil && !v.Union && v.Restriction.hasFacets() {
+		return trimNSPrefix(value), nil
+	}
+	return opt.GetValueType(value, XSDSchema)
+}
+
 // GetValueType convert XSD schema value type to the build-in type for the
 // given value and proto tree.
 func (opt *Options) GetValueType(value string, XSDSchema []interface{}) (valueType string, err error) {
-	if buildType, ok := getBuildInTypeByLang(trimNSPrefix(value), opt.Lang); ok {
+	if buildType, ok := opt.buildInType(trimNSPrefix(value)); ok {
 		valueType = buildType
 		return
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), XSDSchema)
+	valueType = opt.baseType(trimNSPrefix(value), XSDSchema)
 	if valueType != trimNSPrefix(value) && valueType != "" {
 		return
 	}
 	if opt.Extract {
 		return
 	}
-	schemaLocation := opt.NSSchemaLocationMap[opt.parseNS(value)]
-	if isValidURL(schemaLocation) {
+	namespace := opt.parseNS(value)
+	schemaLocation := opt.NSSchemaLocationMap[namespace]
+	xsdFile, cataloged := opt.locateSchema(namespace, schemaLocation)
+	remote := isValidURL(xsdFile)
+	if remote && opt.Fetcher == nil {
+		// remote schemas are skipped without fetcher.
 		return
 	}
-	xsdFile := filepath.Join(opt.FileDir, schemaLocation)
-	var fi os.FileInfo
-	fi, err = os.Stat(xsdFile)
-	if err != nil {
-		return
+	isDir := schemaLocation == "" && !cataloged
+	if !remote {
+		var fi fs.FileInfo
+		fi, err = opt.statFile(xsdFile)
+		if err != nil {
+			err = &ResolveError{Type: value, Location: xsdFile, Err: err}
+			return
+		}
+		isDir = fi.IsDir()
 	}
-	if fi.IsDir() {
+	if isDir {
 		// extract type of value from include schema.
 		valueType = ""
 		for include := range opt.IncludeMap {
-			parser := NewParser(&Options{
-				FilePath:            filepath.Join(opt.FileDir, include),
-				OutputDir:           opt.OutputDir,
-				Extract:             true,
-				Lang:                opt.Lang,
-				IncludeMap:          opt.IncludeMap,
-				LocalNameNSMap:      opt.LocalNameNSMap,
-				NSSchemaLocationMap: opt.NSSchemaLocationMap,
-				ParseFileList:       opt.ParseFileList,
-				ParseFileMap:        opt.ParseFileMap,
-				ProtoTree:           make([]interface{}, 0),
-				Hook:                opt.Hook,
-			})
-			if parser.Parse() != nil {
+			location, _ := opt.locateSchema("", include)
+			if isValidURL(location) && opt.Fetcher == nil {
+				continue
+			}
+			var schema *Schema
+			if schema, err = opt.parseSchema(location, true); err != nil {
+				err = &ResolveError{Type: value, Location: location, Err: err}
 				return
 			}
-			if vt := getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree); vt != trimNSPrefix(value) {
+			if vt := schema.baseType(trimNSPrefix(value), schema.ProtoTree); vt != trimNSPrefix(value) {
 				valueType = vt
 			}
 		}
@@ -242,46 +424,210 @@ func (opt *Options) GetValueType(value string, XSDSchema []interface{}) (valueTy
 		return
 	}
 
+	// the schemas which are still being parsed have no symbol tables, and
+	// their proto trees are scanned
+	depSchema := opt.SchemaMap[xsdFile]
 	depXSDSchema, ok := opt.ParseFileMap[xsdFile]
 	if !ok {
-		parser := NewParser(&Options{
-			FilePath:            xsdFile,
-			OutputDir:           opt.OutputDir,
-			Extract:             false,
-			Lang:                opt.Lang,
-			IncludeMap:          opt.IncludeMap,
-			LocalNameNSMap:      opt.LocalNameNSMap,
-			NSSchemaLocationMap: opt.NSSchemaLocationMap,
-			ParseFileList:       opt.ParseFileList,
-			ParseFileMap:        opt.ParseFileMap,
-			ProtoTree:           make([]interface{}, 0),
-			Hook:                opt.Hook,
-		})
-		if parser.Parse() != nil {
+		if depSchema, err = opt.parseSchema(xsdFile, false); err != nil {
+			err = &ResolveError{Type: value, Location: xsdFile, Err: err}
 			return
 		}
-		depXSDSchema = parser.ProtoTree
+		depXSDSchema = depSchema.ProtoTree
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), depXSDSchema)
+	valueType = depSchema.baseType(trimNSPrefix(value), depXSDSchema)
 	if valueType != trimNSPrefix(value) && valueType != "" {
 		return
 	}
-	parser := NewParser(&Options{
-		FilePath:            xsdFile,
+	if depSchema, err = opt.parseSchema(xsdFile, true); err != nil {
+		err = &ResolveError{Type: value, Location: xsdFile, Err: err}
+		return
+	}
+	valueType = depSchema.baseType(trimNSPrefix(value), depSchema.ProtoTree)
+	return
+}
+
+// parseSchema returns the schema model of the included or imported schema
+// file from the schema cache of the options, or parses it if it isn't
+// cached. The cached schema model is added to the schema map for linking the
+// schemas, and the code generated for it is written to the output of the
+// options. The schemas parsed without resolving types are only parsed once
+// for the input file.
+func (opt *Options) parseSchema(filePath string, extract bool) (*Schema, error) {
+	if schema, ok := opt.extractFileMap[filePath]; ok && extract {
+		return schema, nil
+	}
+	if schema, ok := opt.Cache.load(opt.schemaCacheKey(filePath, extract)); ok {
+		if _, ok = opt.SchemaMap[filePath]; !ok {
+			opt.SchemaMap[filePath] = schema
+		}
+		if !extract {
+			opt.addGenerated(schema)
+			if err := opt.writeCached(schema); err != nil {
+				return nil, err
+			}
+		}
+		return schema, nil
+	}
+	parser := opt.subParser(filePath, extract)
+	if err := parser.Parse(); err != nil {
+		return nil, err
+	}
+	opt.addGenerated(parser.Schema)
+	if extract {
+		if opt.extractFileMap == nil {
+			opt.extractFileMap = make(map[string]*Schema)
+		}
+		opt.extractFileMap[filePath] = parser.Schema
+	}
+	return parser.Schema, nil
+}
+
+// schemaError wraps the error occurred in processing the given XSD construct
+// with the position in the schema file.
+func (opt *Options) schemaError(line, column int, construct string, err error) error {
+	return &SchemaError{File: opt.FilePath, Line: line, Column: column, Construct: construct, Err: err}
+}
+
+// schemaCacheKey returns the key of the schema file in the schema cache for
+// the options which the proto tree and the generated code depend on.
+func (opt *Options) schemaCacheKey(file string, extract bool) schemaCacheKey {
+	namespaces := make([]string, 0, len(opt.NamespacePackages))
+	for namespace, pkg := range opt.NamespacePackages {
+		namespaces = append(namespaces, namespace+"="+pkg)
+	}
+	sort.Strings(namespaces)
+	options := fmt.Sprintf("%q %q %q %q %t %t %q %q %q", opt.Lang, opt.Package, opt.OutputDir, opt.ImportPath, opt.Validation, opt.XSDTimeTypes, opt.Optionality, namespaces, opt.Catalogs)
+	return schemaCacheKey{file: file, options: options, extract: extract}
+}
+
+// subParser creates the parser options for the included or imported schema
+// file, which shares the user-defined overrides and the maps of parsed schemas
+// with opt.
+func (opt *Options) subParser(filePath string, extract bool) *Options {
+	return NewParser(&Options{
+		FilePath:            filePath,
 		OutputDir:           opt.OutputDir,
-		Extract:             true,
+		Extract:             extract,
 		Lang:                opt.Lang,
+		Package:             opt.Package,
+		Validation:          opt.Validation,
+		XSDTimeTypes:        opt.XSDTimeTypes,
+		Optionality:         opt.Optionality,
+		FS:                  opt.FS,
+		Output:              opt.Output,
+		Fetcher:             opt.Fetcher,
+		Catalogs:            opt.Catalogs,
 		IncludeMap:          opt.IncludeMap,
 		LocalNameNSMap:      opt.LocalNameNSMap,
 		NSSchemaLocationMap: opt.NSSchemaLocationMap,
 		ParseFileList:       opt.ParseFileList,
 		ParseFileMap:        opt.ParseFileMap,
+		SchemaMap:           opt.SchemaMap,
+		Cache:               opt.Cache,
 		ProtoTree:           make([]interface{}, 0),
+		RemoteSchema:        opt.RemoteSchema,
 		Hook:                opt.Hook,
+		NamespacePackages:   opt.NamespacePackages,
+		ImportPath:          opt.ImportPath,
+		catalog:             opt.catalog,
+		extractFileMap:      opt.extractFileMap,
 	})
-	if parser.Parse() != nil {
-		return
+}
+
+// fetch returns the content of the remote schema with the given URL by the
+// fetcher of the options. The fetched schemas are kept in the remote schema
+// map, which is shared with the included or imported schemas, so that each
+// remote schema is fetched only once.
+func (opt *Options) fetch(location string) ([]byte, error) {
+	if body, ok := opt.RemoteSchema[location]; ok {
+		return body, nil
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree)
-	return
+	if opt.Fetcher == nil {
+		return nil, fmt.Errorf("xgen: fetch %s: no fetcher specified", location)
+	}
+	body, err := opt.Fetcher.Fetch(location)
+	if err != nil {
+		return nil, err
+	}
+	if opt.RemoteSchema == nil {
+		opt.RemoteSchema = make(map[string][]byte)
+	}
+	opt.RemoteSchema[location] = body
+	return body, nil
+}
+
+// resolveLocation returns the path or URL of the schema at the given location
+// of the <import> or <include> statement. Relative locations in a remote
+// schema are resolved against its URL.
+func (opt *Options) resolveLocation(location string) string {
+	if isValidURL(location) {
+		return location
+	}
+	if isValidURL(opt.FilePath) {
+		base, err := url.Parse(opt.FilePath)
+		if err != nil {
+			return location
+		}
+		ref, err := url.Parse(location)
+		if err != nil {
+			return location
+		}
+		return base.ResolveReference(ref).String()
+	}
+	return opt.joinPath(opt.FileDir, location)
+}
+
+// outputPath returns the path of the generated source code file without the
+// extension of the language. The code of a remote schema is generated in the
+// directory named by the host of its URL in the output directory, and the
+// code of a schema whose target namespace is mapped to a package is generated
+// in the directory of the package.
+func (opt *Options) outputPath() string {
+	if opt.Schema != nil {
+		if pkg := strings.Trim(opt.NamespacePackages[opt.Schema.TargetNamespace], "/"); pkg != "" {
+			return filepath.Join(opt.OutputDir, filepath.FromSlash(pkg), path.Base(filepath.ToSlash(opt.FilePath)))
+		}
+	}
+	if isValidURL(opt.FilePath) {
+		if u, err := url.Parse(opt.FilePath); err == nil {
+			return filepath.Join(opt.OutputDir, u.Host, filepath.FromSlash(u.Path))
+		}
+	}
+	return filepath.Join(opt.OutputDir, strings.TrimPrefix(opt.FilePath, opt.InputDir))
+}
+
+// statFile returns the file info of the given schema file from the FS of the
+// options, or from the operating system file system if the FS is nil.
+func (opt *Options) statFile(name string) (fs.FileInfo, error) {
+	if opt.FS != nil {
+		return fs.Stat(opt.FS, name)
+	}
+	return os.Stat(name)
+}
+
+// openFile opens the given schema file from the FS of the options, or from
+// the operating system file system if the FS is nil.
+func (opt *Options) openFile(name string) (io.ReadCloser, error) {
+	if opt.FS != nil {
+		return opt.FS.Open(name)
+	}
+	return os.Open(name)
+}
+
+// joinPath joins the schema file path elements. The paths in the FS of the
+// options are always slash-separated.
+func (opt *Options) joinPath(elem ...string) string {
+	if opt.FS != nil {
+		return path.Join(elem...)
+	}
+	return filepath.Join(elem...)
+}
+
+// dirPath returns the directory of the given schema file path.
+func (opt *Options) dirPath(name string) string {
+	if opt.FS != nil {
+		return path.Dir(name)
+	}
+	return filepath.Dir(name)
 }
@@ -9,13 +9,21 @@
 package xgen
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +56,14 @@ func TestParseGoExternal(t *testing.T) {
 // The test cleans up files it generates unless leaveOutput is set to true. In which case, the generate file is left
 // on disk for manual inspection under <sourceDirectory>/<langDirName>/output.
 func testParseForSource(t *testing.T, lang string, fileExt string, langDirName string, sourceDirectory string, leaveOutput bool, hook Hook) {
+	testParseForSourceWithOptions(t, lang, fileExt, langDirName, sourceDirectory, leaveOutput, func(opt *Options) {
+		opt.Hook = hook
+	})
+}
+
+// testParseForSourceWithOptions runs parsing tests like testParseForSource, the
+// setup function is called to override the parser options for each file.
+func testParseForSourceWithOptions(t *testing.T, lang string, fileExt string, langDirName string, sourceDirectory string, leaveOutput bool, setup func(opt *Options)) {
 	codeDir := filepath.Join(sourceDirectory, langDirName)
 
 	outputDir := filepath.Join(codeDir, "output")
@@ -87,8 +103,8 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 					ParseFileList:       make(map[string]bool),
 					ParseFileMap:        make(map[string][]interface{}),
 					ProtoTree:           make([]interface{}, 0),
-					Hook:                hook,
 				})
+				setup(parser)
 				err = parser.Parse()
 				assert.NoError(t, err, file)
 				generatedFileName := strings.TrimPrefix(file, inputDir) + "." + fileExt
@@ -107,6 +123,1113 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 	}
 }
 
+// TestParseGoWithValidation runs tests on the XSDs within the validation
+// fixture directory, with the Validate methods generation enabled.
+func TestParseGoWithValidation(t *testing.T) {
+	testParseForSourceWithOptions(t, "Go", "go", "go", filepath.Join(testFixtureDir, "validation"), false, func(opt *Options) {
+		opt.Validation = true
+	})
+}
+
+// TestParseGoWithXSDTimeTypes runs tests on the XSDs within the xsdtime
+// fixture directory, with the date and time types of the xsd runtime package.
+func TestParseGoWithXSDTimeTypes(t *testing.T) {
+	testParseForSourceWithOptions(t, "Go", "go", "go", filepath.Join(testFixtureDir, "xsdtime"), false, func(opt *Options) {
+		opt.XSDTimeTypes = true
+	})
+}
+
+// TestParseGoWithOptionality runs tests on the XSDs within the optional
+// fixture directory, with each of the optionality policies of Go.
+func TestParseGoWithOptionality(t *testing.T) {
+	for _, optionality := range []string{OptionalPointer, OptionalComplex, OptionalGeneric} {
+		t.Run(optionality, func(t *testing.T) {
+			testParseForSourceWithOptions(t, "Go", "go", optionality, filepath.Join(testFixtureDir, "optional"), false, func(opt *Options) {
+				opt.Validation = true
+				opt.Optionality = optionality
+			})
+		})
+	}
+}
+
+// testSchemaFS is an in-memory file system with a schema which imports the
+// type of its element from another schema in a sub directory.
+var testSchemaFS = fstest.MapFS{
+	"schemas/order.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common" targetNamespace="urn:order">
+  <import namespace="urn:common" schemaLocation="common/identifier.xsd"/>
+  <complexType name="Order">
+    <sequence>
+      <element name="id" type="c:identifier"/>
+    </sequence>
+  </complexType>
+</schema>`)},
+	"schemas/common/identifier.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:common">
+  <simpleType name="identifier">
+    <restriction base="int"/>
+  </simpleType>
+</schema>`)},
+}
+
+func TestParseFS(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		outputDir := t.TempDir()
+		err := NewParser(&Options{OutputDir: outputDir, Lang: "Go"}).ParseFS(testSchemaFS, "schemas/order.xsd")
+		require.NoError(t, err)
+
+		generated, err := ioutil.ReadFile(filepath.Join(outputDir, "order.xsd.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		outputDir := t.TempDir()
+		err := NewParser(&Options{OutputDir: outputDir, Lang: "Go"}).ParseFS(testSchemaFS, "schemas")
+		require.NoError(t, err)
+
+		generated, err := ioutil.ReadFile(filepath.Join(outputDir, "order.xsd.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+		generated, err = ioutil.ReadFile(filepath.Join(outputDir, "common", "identifier.xsd.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(generated), "type Identifier int")
+	})
+
+	t.Run("not exist", func(t *testing.T) {
+		err := NewParser(&Options{OutputDir: t.TempDir(), Lang: "Go"}).ParseFS(testSchemaFS, "schemas/missing.xsd")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestParseReader(t *testing.T) {
+	outputDir := t.TempDir()
+	parser := NewParser(&Options{OutputDir: outputDir, Lang: "Go", FS: testSchemaFS})
+	err := parser.ParseReader("schemas/order.xsd", bytes.NewReader(testSchemaFS["schemas/order.xsd"].Data))
+	require.NoError(t, err)
+
+	generated, err := ioutil.ReadFile(filepath.Join(outputDir, "order.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+	assert.Len(t, parser.ProtoTree, 1)
+}
+
+func TestParseRemoteSchema(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.FileServer(http.FS(testSchemaFS)).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	schemaURL := server.URL + "/schemas/order.xsd"
+	host := strings.TrimPrefix(server.URL, "http://")
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	t.Run("fetch", func(t *testing.T) {
+		output := NewMemoryOutput()
+		err := NewParser(&Options{
+			FilePath:  schemaURL,
+			OutputDir: "output",
+			Lang:      "Go",
+			Output:    output,
+			Fetcher:   &HTTPFetcher{Client: server.Client(), CacheDir: cacheDir},
+		}).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+
+		generated, ok := output.File(filepath.Join("output", host, "schemas", "order.xsd.go"))
+		assert.True(t, ok)
+		assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+		_, ok = output.File(filepath.Join("output", host, "schemas", "common", "identifier.xsd.go"))
+		assert.True(t, ok)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		output := NewMemoryOutput()
+		err := NewParser(&Options{
+			FilePath:  schemaURL,
+			OutputDir: "output",
+			Lang:      "Go",
+			Output:    output,
+			Fetcher:   &HTTPFetcher{CacheDir: cacheDir, Offline: true},
+		}).Parse()
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests, "cached schemas should not be fetched")
+		assert.Len(t, output.Names(), 2)
+
+		err = NewParser(&Options{
+			FilePath: schemaURL,
+			Lang:     "Go",
+			Output:   NewMemoryOutput(),
+			Fetcher:  &HTTPFetcher{CacheDir: t.TempDir(), Offline: true},
+		}).Parse()
+		assert.ErrorIs(t, err, ErrOffline)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := NewParser(&Options{
+			FilePath: server.URL + "/schemas/missing.xsd",
+			Lang:     "Go",
+			Output:   NewMemoryOutput(),
+			Fetcher:  &HTTPFetcher{Client: server.Client()},
+		}).Parse()
+		assert.EqualError(t, err, "xgen: fetch "+server.URL+"/schemas/missing.xsd: unexpected status 404 Not Found")
+	})
+
+	t.Run("without fetcher", func(t *testing.T) {
+		schema := strings.Replace(string(testSchemaFS["schemas/order.xsd"].Data), "common/identifier.xsd", server.URL+"/schemas/common/identifier.xsd", 1)
+		output := NewMemoryOutput()
+		err := NewParser(&Options{OutputDir: "output", Lang: "Go", Output: output}).ParseReader("order.xsd", strings.NewReader(schema))
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join("output", "order.xsd.go")}, output.Names())
+	})
+}
+
+func TestParseCatalog(t *testing.T) {
+	fsys := fstest.MapFS{
+		"catalog.xml": &fstest.MapFile{Data: []byte(`<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <uri name="urn:common" uri="schemas/common/identifier.xsd"/>
+  <system systemId="http://example.com/identifier.xsd" uri="schemas/common/identifier.xsd"/>
+  <rewriteSystem systemIdStartString="http://example.com/" rewritePrefix="schemas/"/>
+  <nextCatalog catalog="catalogs/next.xml"/>
+</catalog>`)},
+		"catalogs/next.xml": &fstest.MapFile{Data: []byte(`<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <rewriteURI uriStartString="http://example.org/xsd/" rewritePrefix="../schemas/common/"/>
+</catalog>`)},
+		"schemas/common/identifier.xsd": testSchemaFS["schemas/common/identifier.xsd"],
+	}
+	for _, c := range []struct {
+		name, namespace, location string
+	}{
+		{"uri", "urn:common", ""},
+		{"system", "urn:other", "http://example.com/identifier.xsd"},
+		{"rewriteSystem", "urn:other", "http://example.com/common/identifier.xsd"},
+		{"rewriteURI", "urn:other", "http://example.org/xsd/identifier.xsd"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			imports := fmt.Sprintf(`<import namespace="%s"/>`, c.namespace)
+			if c.location != "" {
+				imports = fmt.Sprintf(`<import namespace="%s" schemaLocation="%s"/>`, c.namespace, c.location)
+			}
+			schema := strings.NewReplacer(
+				`<import namespace="urn:common" schemaLocation="common/identifier.xsd"/>`, imports,
+				`xmlns:c="urn:common"`, `xmlns:c="`+c.namespace+`"`,
+			).Replace(string(testSchemaFS["schemas/order.xsd"].Data))
+			output := NewMemoryOutput()
+			err := NewParser(&Options{
+				OutputDir: "output",
+				Lang:      "Go",
+				FS:        fsys,
+				Output:    output,
+				Catalogs:  []string{"catalog.xml"},
+			}).ParseReader("schemas/order.xsd", strings.NewReader(schema))
+			require.NoError(t, err)
+			generated, ok := output.File(filepath.Join("output", "order.xsd.go"))
+			assert.True(t, ok)
+			assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+		})
+	}
+
+	t.Run("not exist", func(t *testing.T) {
+		err := NewParser(&Options{
+			Lang:     "Go",
+			FS:       fsys,
+			Output:   NewMemoryOutput(),
+			Catalogs: []string{"missing.xml"},
+		}).ParseReader("schemas/order.xsd", bytes.NewReader(testSchemaFS["schemas/order.xsd"].Data))
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestParseSchemaModel(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schemas/order.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common" xmlns:o="urn:order" targetNamespace="urn:order" elementFormDefault="qualified">
+  <import namespace="urn:common" schemaLocation="common/identifier.xsd"/>
+  <include schemaLocation="line.xsd"/>
+  <complexType name="Order">
+    <sequence>
+      <element name="id" type="c:identifier"/>
+      <element ref="o:line" maxOccurs="unbounded"/>
+    </sequence>
+  </complexType>
+  <element name="order" type="o:Order"/>
+</schema>`)},
+		"schemas/line.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <element name="line" type="string"/>
+</schema>`)},
+		"schemas/common/identifier.xsd": testSchemaFS["schemas/common/identifier.xsd"],
+	}
+	parser := NewParser(&Options{OutputDir: "output", Lang: "Go", FS: fsys, Output: NewMemoryOutput()})
+	require.NoError(t, parser.ParseFS(fsys, "schemas/order.xsd"))
+
+	schema := parser.Schema
+	assert.Equal(t, "schemas/order.xsd", schema.FilePath)
+	assert.Equal(t, "urn:order", schema.TargetNamespace)
+	assert.Equal(t, "qualified", schema.ElementFormDefault)
+	assert.Equal(t, "unqualified", schema.AttributeFormDefault)
+	require.Len(t, schema.Imports, 1)
+	require.Len(t, schema.Includes, 1)
+	assert.Same(t, parser.SchemaMap["schemas/common/identifier.xsd"], schema.Imports[0])
+	assert.Equal(t, "urn:common", schema.Imports[0].TargetNamespace)
+
+	order := schema.ComplexTypes[xml.Name{Space: "urn:order", Local: "Order"}]
+	require.NotNil(t, order)
+	assert.Same(t, order, schema.ComplexType(xml.Name{Space: "urn:order", Local: "Order"}))
+	assert.Equal(t, xml.Name{Space: "urn:order", Local: "Order"}, schema.Element(xml.Name{Space: "urn:order", Local: "order"}).TypeName)
+
+	// cross-file references are resolved to the components in the imported
+	// and included schemas
+	require.Len(t, order.Elements, 2)
+	identifier := schema.SimpleType(order.Elements[0].TypeName)
+	require.NotNil(t, identifier)
+	assert.Same(t, schema.Imports[0].SimpleTypes[xml.Name{Space: "urn:common", Local: "identifier"}], identifier)
+	line := schema.Element(order.Elements[1].Ref)
+	require.NotNil(t, line)
+	assert.Equal(t, xml.Name{Space: "http://www.w3.org/2001/XMLSchema", Local: "string"}, line.TypeName)
+	assert.Nil(t, schema.ComplexType(xml.Name{Space: "urn:common", Local: "Order"}))
+
+	// the references of the components are linked to the components
+	assert.Same(t, identifier, order.Elements[0].SimpleTypeDef)
+	assert.Nil(t, order.Elements[0].ComplexTypeDef)
+	assert.Same(t, line, order.Elements[1].RefElement)
+	assert.Same(t, order, schema.Element(xml.Name{Space: "urn:order", Local: "order"}).ComplexTypeDef)
+	assert.Nil(t, line.SimpleTypeDef)
+}
+
+func TestParseWildcard(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "any.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	envelope := parser.Schema.ComplexType(xml.Name{Local: "Envelope"})
+	require.NotNil(t, envelope)
+	require.Len(t, envelope.Elements, 2)
+	assert.Equal(t, Element{Wildcard: true, Namespace: "##any", ProcessContents: "lax", Plural: true, Optional: true}, envelope.Elements[1])
+	require.Len(t, envelope.Attributes, 2)
+	assert.Equal(t, Attribute{Wildcard: true, Namespace: "##any", ProcessContents: "strict", Optional: true}, envelope.Attributes[1])
+
+	group := parser.Schema.Group(xml.Name{Local: "extensionGroup"})
+	require.NotNil(t, group)
+	require.Len(t, group.Elements, 2)
+	assert.Equal(t, Element{Wildcard: true, Namespace: "##other", ProcessContents: "skip", Optional: true}, group.Elements[1])
+	attributeGroup := parser.Schema.AttributeGroup(xml.Name{Local: "extensionAttributes"})
+	require.NotNil(t, attributeGroup)
+	require.Len(t, attributeGroup.Attributes, 2)
+	assert.Equal(t, "##other", attributeGroup.Attributes[1].Namespace)
+	assert.Equal(t, "lax", attributeGroup.Attributes[1].ProcessContents)
+}
+
+func TestParseSubstitutionGroup(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "substitution.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	shape := parser.Schema.Element(xml.Name{Local: "shape"})
+	require.NotNil(t, shape)
+	assert.True(t, shape.Abstract)
+	var members []string
+	for _, member := range parser.Schema.Substitutes(xml.Name{Local: "shape"}) {
+		members = append(members, member.Name)
+	}
+	assert.Equal(t, []string{"circle", "square", "cube"}, members)
+	assert.Equal(t, xml.Name{Local: "square"}, parser.Schema.Element(xml.Name{Local: "cube"}).SubstitutionGroup)
+	assert.Empty(t, parser.Schema.Substitutes(xml.Name{Local: "circle"}))
+}
+
+func TestParseDefaults(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "defaults.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	ticket := parser.Schema.ComplexType(xml.Name{Space: "http://example.org/defaults", Local: "Ticket"})
+	require.NotNil(t, ticket)
+	require.Len(t, ticket.Attributes, 3)
+	assert.Equal(t, "en", ticket.Attributes[0].Default)
+	assert.False(t, ticket.Attributes[0].Fixed)
+	assert.Equal(t, "2.0", ticket.Attributes[1].Default)
+	assert.True(t, ticket.Attributes[1].Fixed)
+	require.Len(t, ticket.Elements, 6)
+	assert.Equal(t, "1", ticket.Elements[1].Default)
+	assert.False(t, ticket.Elements[1].Fixed)
+	assert.Equal(t, "EUR", ticket.Elements[4].Default)
+	assert.True(t, ticket.Elements[4].Fixed)
+
+	attributeGroup := parser.Schema.AttributeGroup(xml.Name{Space: "http://example.org/defaults", Local: "auditAttrs"})
+	require.NotNil(t, attributeGroup)
+	assert.Equal(t, "1", attributeGroup.Attributes[1].Default)
+	assert.True(t, attributeGroup.Attributes[1].Fixed)
+}
+
+func TestParseIdentityConstraints(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "validation", "xsd", "validation.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+	space := "http://example.org/validation"
+
+	order := parser.Schema.ComplexType(xml.Name{Space: space, Local: "Order"})
+	require.NotNil(t, order)
+	assert.Equal(t, []IdentityConstraint{
+		{Kind: "key", Name: "lineSku", Selector: "tns:line", Fields: []string{"tns:sku"}},
+		{Kind: "unique", Name: "lineNumber", Selector: "tns:line", Fields: []string{"@number"}},
+		{Kind: "keyref", Name: "relatedLine", Refer: xml.Name{Space: space, Local: "lineSku"}, Selector: "tns:related", Fields: []string{"."}},
+	}, order.IdentityConstraints)
+	require.Len(t, order.Elements, 4)
+	assert.Empty(t, order.Elements[2].IdentityConstraints)
+	assert.Equal(t, []IdentityConstraint{
+		{Kind: "unique", Name: "bundleLine", Selector: "tns:line", Fields: []string{"tns:sku"}},
+	}, order.Elements[3].IdentityConstraints)
+
+	batch := parser.Schema.Element(xml.Name{Space: space, Local: "Batch"})
+	require.NotNil(t, batch)
+	assert.Equal(t, []IdentityConstraint{
+		{Kind: "unique", Name: "batchLine", Selector: ".//tns:line", Fields: []string{"tns:sku", "tns:price/@currency"}},
+	}, batch.IdentityConstraints)
+}
+
+func TestParseAssertions(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "assertion.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	priceRange := parser.Schema.ComplexType(xml.Name{Local: "PriceRange"})
+	require.NotNil(t, priceRange)
+	assert.Equal(t, []Assertion{{Test: "low le high"}}, priceRange.Assertions)
+
+	evenNumber := parser.Schema.SimpleType(xml.Name{Local: "EvenNumber"})
+	require.NotNil(t, evenNumber)
+	assert.Equal(t, []Assertion{{Test: "$value mod 2 = 0"}}, evenNumber.Restriction.Assertions)
+
+	publication := parser.Schema.Element(xml.Name{Local: "publication"})
+	require.NotNil(t, publication)
+	assert.Equal(t, []Alternative{
+		{Test: "@kind = 'book'", Type: "BookType", TypeName: xml.Name{Local: "BookType"}},
+		{Test: `@kind eq 'magazine' or @kind = "journal"`, Type: "MagazineType", TypeName: xml.Name{Local: "MagazineType"}},
+		{Type: "PublicationType", TypeName: xml.Name{Local: "PublicationType"}},
+	}, publication.Alternatives)
+
+	library := parser.Schema.ComplexType(xml.Name{Local: "Library"})
+	require.NotNil(t, library)
+	require.Len(t, library.Elements, 3)
+	assert.Empty(t, library.Elements[1].Alternatives)
+	assert.Equal(t, []Alternative{
+		{Test: "fn:not(@kind != 'book')", Type: "BookType", TypeName: xml.Name{Local: "BookType"}},
+	}, library.Elements[2].Alternatives)
+}
+
+func TestGoAlternativeTest(t *testing.T) {
+	for test, expected := range map[string]string{
+		"@kind = 'book'":              `attrs["kind"] != nil && *attrs["kind"] == "book"`,
+		`'it''s' ne @x:title`:         `attrs["title"] != nil && *attrs["title"] != "it's"`,
+		"@a = @b and not(@c)":         `attrs["a"] != nil && attrs["b"] != nil && *attrs["a"] == *attrs["b"] && !(attrs["c"] != nil)`,
+		"(attribute::a or @b) and @c": `(attrs["a"] != nil || attrs["b"] != nil) && attrs["c"] != nil`,
+		"@version = 2":                "",
+		"'a' = 'b'":                   "",
+		"@kind = ('a', 'b')":          "",
+		"../@kind = 'a'":              "",
+		"@kind = 'unterminated":       "",
+		"@kind = 'a' and":             "",
+		"string-length(@kind) gt 0":   "",
+		"@kind = 'a' @kind":           "",
+	} {
+		cond, ok := goAlternativeTest(test)
+		assert.Equal(t, expected != "", ok, test)
+		assert.Equal(t, expected, cond, test)
+	}
+}
+
+func TestParseNamespaces(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "namespace.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+	space := "http://example.org/inventory"
+	assert.Equal(t, "qualified", parser.Schema.ElementFormDefault)
+	assert.Equal(t, "unqualified", parser.Schema.AttributeFormDefault)
+
+	warehouse := parser.Schema.Element(xml.Name{Space: space, Local: "warehouse"})
+	require.NotNil(t, warehouse)
+	assert.Equal(t, space, warehouse.TargetNamespace)
+	audited := parser.Schema.Attribute(xml.Name{Space: space, Local: "audited"})
+	require.NotNil(t, audited)
+	assert.Equal(t, space, audited.TargetNamespace)
+
+	item := parser.Schema.ComplexType(xml.Name{Space: space, Local: "StockItem"})
+	require.NotNil(t, item)
+	assert.False(t, item.Element)
+	require.Len(t, item.Elements, 3)
+	for i, expected := range []string{space, "", space} {
+		assert.Equal(t, expected, item.Elements[i].TargetNamespace, item.Elements[i].Name)
+	}
+	require.Len(t, item.Attributes, 3)
+	for i, expected := range []string{"", space, space} {
+		assert.Equal(t, expected, item.Attributes[i].TargetNamespace, item.Attributes[i].Name)
+	}
+
+	inventory := parser.Schema.ComplexType(xml.Name{Space: space, Local: "Inventory"})
+	require.NotNil(t, inventory)
+	assert.True(t, inventory.Element)
+}
+
+func TestParseUnionMembers(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "list.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	// the members of the unions keep the declaration order
+	types := make(map[string]*SimpleType)
+	for _, ele := range parser.ProtoTree {
+		if v, ok := ele.(*SimpleType); ok {
+			types[v.Name] = v
+		}
+	}
+	assert.Equal(t, []string{"int", "SizeName"}, types["ClothingSize"].Members)
+	assert.Equal(t, map[string]string{"int": "int", "SizeName": "string"}, types["ClothingSize"].MemberTypes)
+	assert.Equal(t, []string{"boolean", "decimal", "string"}, types["Threshold"].Members)
+	assert.True(t, types["Sizes"].List)
+}
+
+func TestParseNestedTypes(t *testing.T) {
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "nested.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput()})
+	require.NoError(t, parser.Parse())
+
+	// the anonymous complex types are named by the paths of the elements
+	types := make(map[string]*ComplexType)
+	for _, ele := range parser.ProtoTree {
+		if v, ok := ele.(*ComplexType); ok {
+			types[v.Name] = v
+		}
+	}
+	for name, elements := range map[string][]string{
+		"PurchaseOrder":              {"PurchaseOrderLineItem", "PurchaseOrderShipping"},
+		"PurchaseOrderLineItem":      {"string", "PurchaseOrderLineItemPrice"},
+		"PurchaseOrderShipping":      {"PurchaseOrderShippingPrice"},
+		"PurchaseOrderLineItemPrice": {"float64", "string"},
+		"PurchaseOrderShippingPrice": {"float64", "float64"},
+		"SignatureSigner":            {"string"},
+		"Order":                      {"OrderItem2", "OrderItem"},
+		"OrderItem2":                 {"int"},
+	} {
+		v := types[name]
+		require.NotNil(t, v, name)
+		assert.True(t, v.Anonymous, name)
+		assert.Equal(t, name == "PurchaseOrder" || name == "Order", v.Element, name)
+		require.Len(t, v.Elements, len(elements), name)
+		for i, expected := range elements {
+			assert.Equal(t, expected, v.Elements[i].Type, name)
+		}
+	}
+	assert.True(t, types["Document"].Element)
+	assert.False(t, types["TreeNode"].Anonymous)
+	// the derived names don't hide the types declared after them
+	assert.False(t, types["OrderItem"].Anonymous)
+	require.Len(t, types["OrderItem"].Elements, 1)
+	assert.Equal(t, "sku", types["OrderItem"].Elements[0].Name)
+
+	var signature *Group
+	for _, ele := range parser.ProtoTree {
+		if v, ok := ele.(*Group); ok && v.Name == "Signature" {
+			signature = v
+		}
+	}
+	require.NotNil(t, signature)
+	require.Len(t, signature.Elements, 1)
+	assert.Equal(t, "SignatureSigner", signature.Elements[0].Type)
+	assert.Same(t, types["SignatureSigner"], signature.Elements[0].ComplexTypeDef)
+	assert.Same(t, types["OrderItem2"], types["Order"].Elements[0].ComplexTypeDef)
+	assert.Same(t, types["OrderItem"], types["Order"].Elements[1].ComplexTypeDef)
+	require.Len(t, types["Document"].Groups, 1)
+	assert.Same(t, signature, types["Document"].Groups[0].RefGroup)
+
+	gen := &CodeGenerator{ProtoTree: parser.ProtoTree}
+	assert.True(t, gen.recursiveType("TreeNode", "TreeNode"))
+	assert.True(t, gen.recursiveType("Expression", "Operand"))
+	assert.False(t, gen.recursiveType("Document", "TreeNode"))
+	assert.False(t, gen.recursiveType("PurchaseOrder", "PurchaseOrderLineItem"))
+}
+
+// TestParseNamespacePackages generates the schemas of the package fixture
+// directory, which declare the types of the same name in different
+// namespaces, into the packages mapped to the namespaces.
+func TestParseNamespacePackages(t *testing.T) {
+	sourceDir := filepath.Join(testFixtureDir, "packages")
+	for _, lang := range [][2]string{{"Go", "go"}, {"Java", "java"}, {"Rust", "rs"}, {"TypeScript", "ts"}} {
+		t.Run(lang[0], func(t *testing.T) {
+			// generate into the directory of the committed code to compare
+			// the generated files with the existing ones
+			output := NewMemoryOutput()
+			codeDir := filepath.Join(sourceDir, lang[1])
+			err := NewParser(&Options{
+				FilePath:  filepath.Join(sourceDir, "xsd", "order.xsd"),
+				InputDir:  filepath.Join(sourceDir, "xsd"),
+				OutputDir: codeDir,
+				Lang:      lang[0],
+				Output:    output,
+				NamespacePackages: map[string]string{
+					"http://example.org/order":    "order",
+					"http://example.org/billing":  "billing",
+					"http://example.org/shipping": "shipping",
+				},
+				ImportPath: "github.com/xuri/xgen/test/packages/go",
+			}).Parse()
+			require.NoError(t, err)
+			var names []string
+			for _, pkg := range []string{"billing", "shipping"} {
+				names = append(names, filepath.Join(codeDir, pkg, "address.xsd."+lang[1]))
+			}
+			names = append(names, filepath.Join(codeDir, "order", "order.xsd."+lang[1]))
+			assert.ElementsMatch(t, names, output.Names())
+			for name, generated := range output.Files() {
+				expected, err := ioutil.ReadFile(name)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(generated), name)
+			}
+		})
+	}
+}
+
+func TestParseRedefine(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schemas/base.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="code">
+    <restriction base="string"/>
+  </simpleType>
+  <complexType name="Address">
+    <sequence>
+      <element name="street" type="string"/>
+    </sequence>
+    <attribute name="id" type="string"/>
+  </complexType>
+  <complexType name="Contact">
+    <sequence>
+      <element name="name" type="string"/>
+      <element name="email" type="string" minOccurs="0"/>
+    </sequence>
+    <attribute name="kind" type="string"/>
+  </complexType>
+  <group name="details">
+    <sequence>
+      <element name="note" type="string"/>
+    </sequence>
+  </group>
+  <attributeGroup name="audit">
+    <attribute name="created" type="string"/>
+  </attributeGroup>
+</schema>`)},
+		"schemas/redefine.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <redefine schemaLocation="base.xsd">
+    <simpleType name="code">
+      <restriction base="code">
+        <maxLength value="8"/>
+      </restriction>
+    </simpleType>
+    <complexType name="Address">
+      <complexContent>
+        <extension base="Address">
+          <sequence>
+            <element name="city" type="string"/>
+          </sequence>
+        </extension>
+      </complexContent>
+    </complexType>
+    <complexType name="Contact">
+      <complexContent>
+        <restriction base="Contact">
+          <sequence>
+            <element name="name" type="string"/>
+          </sequence>
+        </restriction>
+      </complexContent>
+    </complexType>
+    <group name="details">
+      <sequence>
+        <group ref="details"/>
+        <element name="tag" type="string"/>
+      </sequence>
+    </group>
+    <attributeGroup name="audit">
+      <attributeGroup ref="audit"/>
+      <attribute name="updated" type="string"/>
+    </attributeGroup>
+  </redefine>
+</schema>`)},
+		"schemas/override.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <override schemaLocation="base.xsd">
+    <complexType name="Address">
+      <sequence>
+        <element name="line" type="string" maxOccurs="unbounded"/>
+      </sequence>
+    </complexType>
+  </override>
+</schema>`)},
+	}
+	parse := func(t *testing.T, file string) (*Options, string) {
+		output := NewMemoryOutput()
+		parser := NewParser(&Options{FilePath: file, InputDir: "schemas", OutputDir: "output", Lang: "Go", FS: fsys, Output: output})
+		require.NoError(t, parser.Parse())
+		generated, ok := output.File(filepath.Join("output", filepath.Base(file)+".go"))
+		require.True(t, ok)
+		return parser, string(generated)
+	}
+
+	parser, generated := parse(t, "schemas/redefine.xsd")
+	require.Len(t, parser.Schema.Includes, 1)
+	t.Run("simple type", func(t *testing.T) {
+		code := parser.Schema.SimpleType(xml.Name{Local: "code"})
+		require.NotNil(t, code)
+		assert.Equal(t, "string", code.Base)
+		assert.Equal(t, 8, code.Restriction.MaxLength)
+		assert.Equal(t, "string", parser.Schema.Includes[0].SimpleType(xml.Name{Local: "code"}).Base)
+	})
+	t.Run("complex type extension", func(t *testing.T) {
+		address := parser.Schema.ComplexType(xml.Name{Local: "Address"})
+		require.NotNil(t, address)
+		assert.Empty(t, address.Base)
+		assert.Contains(t, generated, "type Address struct {\n\tIdAttr *string `xml:\"id,attr,omitempty\"`\n\tStreet string  `xml:\"street\"`\n\tCity   string  `xml:\"city\"`\n}")
+		// the original type is kept in the included schema
+		assert.Len(t, parser.Schema.Includes[0].ComplexType(xml.Name{Local: "Address"}).Elements, 1)
+	})
+	t.Run("complex type restriction", func(t *testing.T) {
+		assert.Contains(t, generated, "type Contact struct {\n\tKindAttr *string `xml:\"kind,attr,omitempty\"`\n\tName     string  `xml:\"name\"`\n}")
+	})
+	t.Run("group", func(t *testing.T) {
+		assert.Contains(t, generated, "type Details struct {\n\tXMLName xml.Name `xml:\"details\"`\n\tNote    string   `xml:\"note\"`\n\tTag     string   `xml:\"tag\"`\n}")
+	})
+	t.Run("attribute group", func(t *testing.T) {
+		assert.Contains(t, generated, "type Audit struct {\n\tXMLName     xml.Name `xml:\"audit\"`\n\tCreatedAttr *string  `xml:\"created,attr,omitempty\"`\n\tUpdatedAttr *string  `xml:\"updated,attr,omitempty\"`\n}")
+	})
+	t.Run("override", func(t *testing.T) {
+		parser, generated := parse(t, "schemas/override.xsd")
+		address := parser.Schema.ComplexType(xml.Name{Local: "Address"})
+		require.NotNil(t, address)
+		require.Len(t, address.Elements, 1)
+		assert.Equal(t, "line", address.Elements[0].Name)
+		assert.Contains(t, generated, "type Address struct {\n\tLine []string `xml:\"line\"`\n}")
+	})
+}
+
+func TestParseMemoryOutput(t *testing.T) {
+	inputDir := filepath.Join(testFixtureDir, "xsd")
+	files, err := GetFileList(inputDir)
+	require.NoError(t, err)
+
+	// generate into the directory of the committed code, so the generated
+	// files can be compared with the existing ones without touching the disk
+	output := NewMemoryOutput()
+	var count int
+	for _, file := range files {
+		if filepath.Ext(file) != ".xsd" {
+			continue
+		}
+		count++
+		err = NewParser(&Options{
+			FilePath:  file,
+			InputDir:  inputDir,
+			OutputDir: filepath.Join(testFixtureDir, "go"),
+			Lang:      "Go",
+			Output:    output,
+		}).Parse()
+		require.NoError(t, err)
+	}
+	assert.Len(t, output.Names(), count)
+	for name, generated := range output.Files() {
+		expected, err := ioutil.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(generated), name)
+	}
+
+	outputDir := filepath.Join(t.TempDir(), "output")
+	err = NewParser(&Options{OutputDir: outputDir, Lang: "TypeScript", Output: output}).ParseFS(testSchemaFS, "schemas")
+	require.NoError(t, err)
+	generated, ok := output.File(filepath.Join(outputDir, "order.xsd.ts"))
+	assert.True(t, ok)
+	assert.Contains(t, string(generated), "export class Order {")
+	_, err = os.Stat(outputDir)
+	assert.True(t, os.IsNotExist(err), "output directory should not be created")
+}
+
+func TestParseConcurrently(t *testing.T) {
+	inputDir := filepath.Join(testFixtureDir, "xsd")
+	files, err := GetFileList(inputDir)
+	require.NoError(t, err)
+
+	// parse each fixture for every language at the same time, run with the
+	// race detector to catch the state shared between parsers or generators
+	var wg sync.WaitGroup
+	outputs := map[string]*MemoryOutput{}
+	for lang, langDirName := range map[string]string{"Go": "go", "TypeScript": "ts", "C": "c", "Java": "java", "Rust": "rs"} {
+		output := NewMemoryOutput()
+		outputs[lang] = output
+		for _, file := range files {
+			if filepath.Ext(file) != ".xsd" {
+				continue
+			}
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func(lang, langDirName, file string) {
+					defer wg.Done()
+					assert.NoError(t, NewParser(&Options{
+						FilePath:  file,
+						InputDir:  inputDir,
+						OutputDir: filepath.Join(testFixtureDir, langDirName),
+						Lang:      lang,
+						Output:    output,
+					}).Parse(), file)
+				}(lang, langDirName, file)
+			}
+		}
+	}
+	wg.Wait()
+	for lang, output := range outputs {
+		assert.NotEmpty(t, output.Names(), lang)
+		for name, generated := range output.Files() {
+			expected, err := ioutil.ReadFile(name)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(generated), name)
+		}
+	}
+}
+
+// countingFS counts the opened files of the file system, the files opened
+// for stat are not counted.
+type countingFS struct {
+	fs.FS
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (f *countingFS) Open(name string) (fs.File, error) {
+	f.mu.Lock()
+	f.counts[name]++
+	f.mu.Unlock()
+	return f.FS.Open(name)
+}
+
+func (f *countingFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(f.FS, name)
+}
+
+func TestParseSchemaCache(t *testing.T) {
+	fsys := &countingFS{FS: testSchemaFS, counts: map[string]int{}}
+	cache := NewSchemaCache()
+	var wg sync.WaitGroup
+	outputs := make([]*MemoryOutput, 4)
+	for i := range outputs {
+		outputs[i] = NewMemoryOutput()
+		// the imported schema is parsed by the first parser, and shared with
+		// the others by the cache
+		parse := func(output *MemoryOutput) {
+			assert.NoError(t, NewParser(&Options{
+				FilePath:  "schemas/order.xsd",
+				InputDir:  "schemas",
+				OutputDir: "output",
+				Lang:      "Go",
+				FS:        fsys,
+				Output:    output,
+				Cache:     cache,
+			}).Parse())
+		}
+		if i == 0 {
+			parse(outputs[i])
+			continue
+		}
+		wg.Add(1)
+		go func(output *MemoryOutput) {
+			defer wg.Done()
+			parse(output)
+		}(outputs[i])
+	}
+	wg.Wait()
+	assert.Equal(t, 4, fsys.counts["schemas/order.xsd"])
+	assert.Equal(t, 1, fsys.counts["schemas/common/identifier.xsd"])
+	for _, output := range outputs {
+		generated, ok := output.File(filepath.Join("output", "order.xsd.go"))
+		assert.True(t, ok)
+		assert.Contains(t, string(generated), "Id int `xml:\"id\"`")
+		// the code of the cached schema is written to the output of each parser
+		generated, ok = output.File(filepath.Join("output", "schemas", "common", "identifier.xsd.go"))
+		assert.True(t, ok)
+		assert.Contains(t, string(generated), "type Identifier int")
+	}
+
+	// the schemas parsed with other options aren't shared
+	assert.NoError(t, NewParser(&Options{
+		FilePath:   "schemas/order.xsd",
+		InputDir:   "schemas",
+		OutputDir:  "output",
+		Lang:       "Go",
+		Validation: true,
+		FS:         fsys,
+		Output:     NewMemoryOutput(),
+		Cache:      cache,
+	}).Parse())
+	assert.Equal(t, 2, fsys.counts["schemas/common/identifier.xsd"])
+}
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestParseOutputFunc(t *testing.T) {
+	var names []string
+	var buf bytes.Buffer
+	err := NewParser(&Options{
+		OutputDir: "output",
+		Lang:      "Rust",
+		FS:        testSchemaFS,
+		Output: OutputFunc(func(name string) (io.WriteCloser, error) {
+			names = append(names, name)
+			return nopCloser{&buf}, nil
+		}),
+	}).ParseReader("schemas/order.xsd", bytes.NewReader(testSchemaFS["schemas/order.xsd"].Data))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("output", "schemas", "common", "identifier.xsd.rs"), filepath.Join("output", "order.xsd.rs")}, names)
+	assert.Contains(t, buf.String(), "pub struct Order {")
+}
+
+type errorWriter struct{}
+
+func (errorWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func (errorWriter) Close() error { return nil }
+
+func TestParseErrors(t *testing.T) {
+	t.Run("output path", func(t *testing.T) {
+		outputDir := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, ioutil.WriteFile(outputDir, nil, 0o644))
+		err := NewParser(&Options{OutputDir: filepath.Join(outputDir, "schemas"), Lang: "Go"}).ParseFS(testSchemaFS, "schemas/common/identifier.xsd")
+		var outputErr *OutputError
+		require.True(t, errors.As(err, &outputErr), err)
+		assert.Equal(t, filepath.Join(outputDir, "schemas", "identifier.xsd.go"), outputErr.Path)
+	})
+
+	for _, lang := range []string{"Go", "TypeScript", "C", "Java", "Rust"} {
+		t.Run("write "+lang, func(t *testing.T) {
+			err := NewParser(&Options{
+				Lang: lang,
+				Output: OutputFunc(func(name string) (io.WriteCloser, error) {
+					return errorWriter{}, nil
+				}),
+			}).ParseFS(testSchemaFS, "schemas/common/identifier.xsd")
+			var outputErr *OutputError
+			require.True(t, errors.As(err, &outputErr), err)
+			assert.EqualError(t, outputErr.Err, "disk full")
+		})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		err := NewParser(&Options{
+			OutputDir: "output",
+			Lang:      "Go",
+			Output: OutputFunc(func(name string) (io.WriteCloser, error) {
+				return nil, os.ErrPermission
+			}),
+		}).ParseFS(testSchemaFS, "schemas/common/identifier.xsd")
+		assert.ErrorIs(t, err, os.ErrPermission)
+		assert.EqualError(t, err, "xgen: write output "+filepath.Join("output", "identifier.xsd.go")+": permission denied")
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		fsys := fstest.MapFS{"order.xsd": testSchemaFS["schemas/order.xsd"]}
+		err := NewParser(&Options{Lang: "Go", Output: NewMemoryOutput()}).ParseFS(fsys, "order.xsd")
+		var resolveErr *ResolveError
+		require.True(t, errors.As(err, &resolveErr), err)
+		assert.Equal(t, "c:identifier", resolveErr.Type)
+		assert.Equal(t, "common/identifier.xsd", resolveErr.Location)
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("resolve schema", func(t *testing.T) {
+		// the errors of the imported schemas are returned without references
+		// to their types
+		fsys := fstest.MapFS{
+			"order.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <import namespace="urn:common" schemaLocation="common.xsd"/>
+</schema>`)},
+			"common.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="Order">`)},
+		}
+		err := NewParser(&Options{Lang: "Go", Output: NewMemoryOutput()}).ParseFS(fsys, "order.xsd")
+		var resolveErr *ResolveError
+		require.True(t, errors.As(err, &resolveErr), err)
+		assert.Empty(t, resolveErr.Type)
+		assert.Equal(t, "common.xsd", resolveErr.Location)
+		var schemaErr *SchemaError
+		require.True(t, errors.As(err, &schemaErr), err)
+		assert.Equal(t, "common.xsd", schemaErr.File)
+		assert.EqualError(t, err, "xgen: resolve schema common.xsd: xgen: common.xsd:2:29: XML syntax error on line 2: unexpected EOF")
+	})
+
+	t.Run("resolve write", func(t *testing.T) {
+		// the error of generating code for the imported schema is returned
+		err := NewParser(&Options{
+			Lang: "Go",
+			Output: OutputFunc(func(name string) (io.WriteCloser, error) {
+				return errorWriter{}, nil
+			}),
+		}).ParseFS(testSchemaFS, "schemas/order.xsd")
+		var resolveErr *ResolveError
+		require.True(t, errors.As(err, &resolveErr), err)
+		var outputErr *OutputError
+		assert.True(t, errors.As(err, &outputErr), err)
+	})
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		schema     string
+		validation bool
+		line       int
+		column     int
+		construct  string
+		err        string
+	}{
+		{
+			name: "truncated",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="Order">
+    <sequence>`,
+			line:   3,
+			column: 15,
+			err:    "xgen: order.xsd:3:15: XML syntax error on line 3: unexpected EOF",
+		},
+		{
+			name: "mismatched",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="Order">
+  </simpleType>
+</schema>`,
+			line:   3,
+			column: 16,
+			err:    "xgen: order.xsd:3:16: XML syntax error on line 3: element <complexType> closed by </simpleType>",
+		},
+		{
+			name: "maxOccurs",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="Order">
+    <sequence>
+      <element name="id" type="string" maxOccurs="many"/>
+    </sequence>
+  </complexType>
+</schema>`,
+			line:      4,
+			column:    7,
+			construct: "element",
+			err:       `xgen: order.xsd:4:7: element: strconv.Atoi: parsing "many": invalid syntax`,
+		},
+		{
+			name: "facet",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="code">
+    <restriction base="string">
+      <maxLength value="ten"/>
+    </restriction>
+  </simpleType>
+</schema>`,
+			line:      4,
+			column:    7,
+			construct: "maxLength",
+			err:       `xgen: order.xsd:4:7: maxLength: strconv.Atoi: parsing "ten": invalid syntax`,
+		},
+		{
+			name: "bound",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="quantity">
+    <restriction base="int">
+      <minInclusive value="one"/>
+    </restriction>
+  </simpleType>
+</schema>`,
+			line:      4,
+			column:    7,
+			construct: "minInclusive",
+			err:       `xgen: order.xsd:4:7: minInclusive: invalid bound "one"`,
+		},
+		{
+			name: "pattern",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="name">
+    <restriction base="string">
+      <pattern value="[\i-[:]][\c-[:]]*"/>
+    </restriction>
+  </simpleType>
+</schema>`,
+			validation: true,
+			line:       4,
+			column:     7,
+			construct:  "pattern",
+			err:        `xgen: order.xsd:4:7: pattern: pattern "[\\i-[:]][\\c-[:]]*": character class subtraction is only supported for characters and ranges`,
+		},
+		{
+			name: "alternative",
+			schema: `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <element name="payment" type="anyType">
+    <alternative test="@kind = 'card'">
+      <complexType/>
+    </alternative>
+  </element>
+</schema>`,
+			line:      3,
+			column:    5,
+			construct: "alternative",
+			err:       "xgen: order.xsd:3:5: alternative: anonymous type of alternative is not supported",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewParser(&Options{Lang: "Go", Validation: tc.validation, Output: NewMemoryOutput()}).ParseReader("order.xsd", strings.NewReader(tc.schema))
+			var schemaErr *SchemaError
+			require.True(t, errors.As(err, &schemaErr), err)
+			assert.Equal(t, "order.xsd", schemaErr.File)
+			assert.Equal(t, tc.line, schemaErr.Line)
+			assert.Equal(t, tc.column, schemaErr.Column)
+			assert.Equal(t, tc.construct, schemaErr.Construct)
+			assert.EqualError(t, err, tc.err)
+			if tc.validation {
+				// the patterns which can't be translated are only reported
+				// for the Validate methods, which would accept any value
+				assert.NoError(t, NewParser(&Options{Lang: "Go", Output: NewMemoryOutput()}).ParseReader("order.xsd", strings.NewReader(tc.schema)))
+			}
+		})
+	}
+
+	// errors of the imported schemas are wrapped with the position of the
+	// reference in the importing schema
+	fsys := fstest.MapFS{
+		"order.xsd": testSchemaFS["schemas/order.xsd"],
+		"common/identifier.xsd": &fstest.MapFile{Data: []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="identifier">`)},
+	}
+	err := NewParser(&Options{Lang: "Go", Output: NewMemoryOutput()}).ParseFS(fsys, "order.xsd")
+	var schemaErr *SchemaError
+	require.True(t, errors.As(err, &schemaErr), err)
+	assert.Equal(t, "order.xsd", schemaErr.File)
+	assert.Equal(t, "element", schemaErr.Construct)
+	var resolveErr *ResolveError
+	require.True(t, errors.As(schemaErr.Err, &resolveErr), err)
+	require.True(t, errors.As(resolveErr.Err, &schemaErr), err)
+	assert.Equal(t, "common/identifier.xsd", schemaErr.File)
+	assert.Equal(t, 2, schemaErr.Line)
+}
+
+func TestTranslatePattern(t *testing.T) {
+	for pattern, expected := range map[string]string{
+		`\d+\.\d{2}`:        `\p{Nd}+\.\p{Nd}{2}`,
+		`$.^`:               `\$[^\n\r]\^`,
+		`\w\W\s\S`:          `[^\p{P}\p{Z}\p{C}][\p{P}\p{Z}\p{C}][ \t\n\r][^ \t\n\r]`,
+		`\p{IsGreek}\P{Lu}`: `[\x{370}-\x{3FF}]\P{Lu}`,
+		`[a-z-[aeiou]]`:     `[b-df-hj-np-tv-z]`,
+		`[^a-z-[m]]`:        "[\\x{0}-`{-\\x{10FFFF}]",
+		`[\-\[\]]`:          `[\-\[\]]`,
+	} {
+		expr, err := translatePattern(pattern)
+		assert.NoError(t, err, pattern)
+		assert.Equal(t, expected, expr, pattern)
+	}
+	for pattern, expected := range map[string]string{
+		`\p{IsUnknown}`: "unsupported Unicode block IsUnknown",
+		`\p{Xx}`:        "unknown character category Xx",
+		`[\W\w]`:        `escape \w in character class is not supported`,
+		`\k`:            `unsupported escape \k`,
+		`[a-z`:          "missing closing ]",
+	} {
+		_, err := translatePattern(pattern)
+		assert.EqualError(t, err, expected, pattern)
+	}
+}
+
 func TestParseTypeScript(t *testing.T) {
 	testParseForSource(t, "TypeScript", "ts", "ts", testFixtureDir, false, nil)
 }
@@ -233,7 +1356,12 @@ func (h *AppinfoHook) OnAddContent(gen *CodeGenerator, content *string) {
 func TestParseGoWithAppinfoHook(t *testing.T) {
 	appinfoHook := &AppinfoHook{}
 	appinfoHook.Appinfo = NewStack()
-	testParseForSource(t, "Go", "go", "go", testFixtureDir, false, appinfoHook)
+	// only the base64 fixture has the appinfo annotations
+	testParseForSourceWithOptions(t, "Go", "go", "go", testFixtureDir, false, func(opt *Options) {
+		if filepath.Base(opt.FilePath) == "base64.xsd" {
+			opt.Hook = appinfoHook
+		}
+	})
 	assert.True(t, appinfoHook.OnStartElementRan)
 	assert.True(t, appinfoHook.OnEndElementRan)
 	assert.True(t, appinfoHook.OnCharDataRan)
@@ -567,8 +1695,6 @@ func (h *OnAddContentTestHook) OnAddContent(gen *CodeGenerator, content *string)
 // TestOnAddContentHookNotNil tests all locations where: if gen.Hook != nil { gen.Hook.OnAddContent(gen, &output) }
 // This covers the TRUE branch (gen.Hook != nil)
 func TestOnAddContentHookNotNil(t *testing.T) {
-	fieldNameCount = make(map[string]int)
-
 	hook := &OnAddContentTestHook{}
 	gen := &CodeGenerator{
 		Lang:      "Go",
@@ -656,8 +1782,6 @@ func TestOnAddContentHookNotNil(t *testing.T) {
 // TestOnAddContentHookIsNil tests all locations where: if gen.Hook != nil { gen.Hook.OnAddContent(gen, &output) }
 // This covers the FALSE branch (gen.Hook == nil)
 func TestOnAddContentHookIsNil(t *testing.T) {
-	fieldNameCount = make(map[string]int)
-
 	gen := &CodeGenerator{
 		Lang:      "Go",
 		StructAST: make(map[string]string),
@@ -738,3 +1862,179 @@ func TestOnAddContentHookIsNil(t *testing.T) {
 		})
 	}
 }
+
+func TestSymbolTable(t *testing.T) {
+	protoTree := []interface{}{
+		&SimpleType{Name: "list", Base: "int", List: true},
+		&Element{Name: "item", Type: "string"},
+		&SimpleType{Name: "code", Base: "string"},
+		&Attribute{Name: "lang", Type: "language"},
+		&SimpleType{Name: "list", Base: "float"},
+		&Element{Name: "code", Type: "int"},
+		&ComplexType{Name: "order"},
+	}
+	opt := NewParser(&Options{ProtoTree: make([]interface{}, 0, 1), symbols: &symbolTable{}})
+	// the lookups in the growing proto tree of the parser give the same
+	// results as the linear lookups, while the proto tree is reallocated
+	for length := 0; length <= len(protoTree); length++ {
+		if length > 0 {
+			opt.ProtoTree = append(opt.ProtoTree, protoTree[length-1])
+		}
+		for _, name := range []string{"list", "item", "code", "lang", "order", "missing"} {
+			assert.Equal(t, getBasefromSimpleType(name, protoTree[:length]), opt.baseType(name, opt.ProtoTree), "%s in %d", name, length)
+			assert.Equal(t, getSimpleType(name, protoTree[:length]), opt.simpleType(name, opt.ProtoTree), "%s in %d", name, length)
+		}
+	}
+	assert.Equal(t, len(protoTree), opt.symbols.indexed)
+	assert.Equal(t, "float", opt.baseType("list", opt.ProtoTree))
+	assert.Equal(t, "code", opt.baseType("code", opt.ProtoTree[:2]))
+	assert.True(t, opt.simpleType("list", opt.ProtoTree).List)
+	// the other proto trees are scanned
+	assert.Equal(t, "string", opt.baseType("code", protoTree))
+	assert.Equal(t, "language", opt.baseType("lang", protoTree))
+
+	// the symbol table of the parsed proto tree is shared with the schema
+	schema := &Schema{ProtoTree: opt.ProtoTree, symbols: opt.symbols}
+	assert.Equal(t, "string", schema.baseType("code", schema.ProtoTree[:3]))
+	assert.Equal(t, "code", schema.baseType("code", schema.ProtoTree[:2]))
+	assert.Equal(t, "language", schema.baseType("lang", protoTree))
+}
+
+// benchmarkSchemaFS returns a file system with the given number of schemas,
+// each of them references every simple type declared in a common schema.
+func benchmarkSchemaFS(files, types int) fstest.MapFS {
+	var common, elements strings.Builder
+	for i := 0; i < types; i++ {
+		common.WriteString(fmt.Sprintf("  <simpleType name=\"type%d\"><restriction base=\"int\"/></simpleType>\n", i))
+		elements.WriteString(fmt.Sprintf("      <element name=\"field%d\" type=\"c:type%d\"/>\n", i, i))
+	}
+	fsys := fstest.MapFS{
+		"common.xsd": &fstest.MapFile{Data: []byte("<schema xmlns=\"http://www.w3.org/2001/XMLSchema\" targetNamespace=\"urn:common\">\n" + common.String() + "</schema>")},
+	}
+	for i := 0; i < files; i++ {
+		fsys[fmt.Sprintf("schema%d.xsd", i)] = &fstest.MapFile{Data: []byte(fmt.Sprintf(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:c="urn:common" targetNamespace="urn:schema%d">
+  <import namespace="urn:common" schemaLocation="common.xsd"/>
+  <complexType name="Record%d">
+    <sequence>
+%s    </sequence>
+  </complexType>
+</schema>`, i, i, elements.String()))}
+	}
+	return fsys
+}
+
+func BenchmarkParseImports(b *testing.B) {
+	fsys := benchmarkSchemaFS(20, 200)
+	for _, c := range []struct {
+		name  string
+		cache bool
+	}{{"parser", false}, {"cache", true}} {
+		b.Run(c.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				var cache *SchemaCache
+				if c.cache {
+					cache = NewSchemaCache()
+				}
+				for i := 0; i < 20; i++ {
+					if err := NewParser(&Options{
+						FilePath:  fmt.Sprintf("schema%d.xsd", i),
+						InputDir:  ".",
+						OutputDir: "output",
+						Lang:      "Go",
+						FS:        fsys,
+						Output:    NewMemoryOutput(),
+						Cache:     cache,
+					}).Parse(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkImportedValueType(b *testing.B) {
+	fsys := benchmarkSchemaFS(1, 200)
+	// the lookups of the types of the imported schema parsed the schema again
+	// with a sub-parser and scanned its proto tree, before the schemas were
+	// indexed by the symbol tables
+	b.Run("reparse", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			parser := NewParser(&Options{FilePath: "common.xsd", OutputDir: "output", Extract: true, Lang: "Go", FS: fsys, Output: NewMemoryOutput()})
+			if err := parser.Parse(); err != nil {
+				b.Fatal(err)
+			}
+			getBasefromSimpleType(fmt.Sprintf("type%d", n%200), parser.ProtoTree)
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		opt := NewParser(&Options{FilePath: "schema0.xsd", InputDir: ".", OutputDir: "output", Lang: "Go", FS: fsys, Output: NewMemoryOutput()})
+		if err := opt.Parse(); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			if valueType, err := opt.GetValueType(fmt.Sprintf("c:type%d", n%200), opt.ProtoTree); err != nil || valueType != "int" {
+				b.Fatal(valueType, err)
+			}
+		}
+	})
+}
+
+func BenchmarkBaseType(b *testing.B) {
+	protoTree := make([]interface{}, 0, 2000)
+	for i := 0; i < cap(protoTree); i++ {
+		protoTree = append(protoTree, &SimpleType{Name: fmt.Sprintf("type%d", i), Base: "int"})
+	}
+	b.Run("linear", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			getBasefromSimpleType(fmt.Sprintf("type%d", n%len(protoTree)), protoTree)
+		}
+	})
+	b.Run("indexed", func(b *testing.B) {
+		opt := NewParser(&Options{ProtoTree: protoTree, symbols: &symbolTable{}})
+		for n := 0; n < b.N; n++ {
+			opt.baseType(fmt.Sprintf("type%d", n%len(protoTree)), protoTree)
+		}
+	})
+}
+
+// AssertionTestHook tests dropping and rewriting the assertions and the type
+// alternatives.
+type AssertionTestHook struct {
+	CharDataSkipHook
+	Assertions, Alternatives int
+}
+
+func (h *AssertionTestHook) OnAssertion(opt *Options, v *Assertion) (next bool, err error) {
+	h.Assertions++
+	if strings.HasPrefix(v.Test, "$value") {
+		return false, nil
+	}
+	v.Test = strings.ToUpper(v.Test)
+	return true, nil
+}
+
+func (h *AssertionTestHook) OnAlternative(opt *Options, v *Alternative) (next bool, err error) {
+	h.Alternatives++
+	// drop the default alternative
+	return v.Test != "", nil
+}
+
+func TestParseAssertionHook(t *testing.T) {
+	hook := &AssertionTestHook{}
+	parser := NewParser(&Options{FilePath: filepath.Join(testFixtureDir, "xsd", "assertion.xsd"), OutputDir: "output", Lang: "Go", Output: NewMemoryOutput(), Hook: hook})
+	require.NoError(t, parser.Parse())
+	assert.Equal(t, 2, hook.Assertions)
+	assert.Equal(t, 4, hook.Alternatives)
+
+	evenNumber := parser.Schema.SimpleType(xml.Name{Local: "EvenNumber"})
+	require.NotNil(t, evenNumber)
+	assert.Empty(t, evenNumber.Restriction.Assertions)
+	priceRange := parser.Schema.ComplexType(xml.Name{Local: "PriceRange"})
+	require.NotNil(t, priceRange)
+	assert.Equal(t, []Assertion{{Test: "LOW LE HIGH"}}, priceRange.Assertions)
+	publication := parser.Schema.Element(xml.Name{Local: "publication"})
+	require.NotNil(t, publication)
+	assert.Len(t, publication.Alternatives, 2)
+}
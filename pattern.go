@@ -0,0 +1,387 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// The characters matched by the \i and \c escapes of the XSD regular
+// expressions, which are the start characters and the characters of the XML
+// names, as the contents of a Go character class.
+const (
+	xsdNameStartChars = `:A-Z_a-z\x{C0}-\x{D6}\x{D8}-\x{F6}\x{F8}-\x{2FF}\x{370}-\x{37D}\x{37F}-\x{1FFF}\x{200C}-\x{200D}\x{2070}-\x{218F}\x{2C00}-\x{2FEF}\x{3001}-\x{D7FF}\x{F900}-\x{FDCF}\x{FDF0}-\x{FFFD}\x{10000}-\x{EFFFF}`
+	xsdNameChars      = xsdNameStartChars + `\-.0-9\x{B7}\x{300}-\x{36F}\x{203F}-\x{2040}`
+)
+
+// xsdBlocks defines the code point ranges of the Unicode blocks matched by
+// the \p{IsX} escapes, which aren't supported by Go regular expressions.
+var xsdBlocks = map[string][2]rune{
+	"BasicLatin":                     {0x0000, 0x007F},
+	"Latin-1Supplement":              {0x0080, 0x00FF},
+	"LatinExtended-A":                {0x0100, 0x017F},
+	"LatinExtended-B":                {0x0180, 0x024F},
+	"IPAExtensions":                  {0x0250, 0x02AF},
+	"SpacingModifierLetters":         {0x02B0, 0x02FF},
+	"CombiningDiacriticalMarks":      {0x0300, 0x036F},
+	"Greek":                          {0x0370, 0x03FF},
+	"Cyrillic":                       {0x0400, 0x04FF},
+	"Armenian":                       {0x0530, 0x058F},
+	"Hebrew":                         {0x0590, 0x05FF},
+	"Arabic":                         {0x0600, 0x06FF},
+	"Devanagari":                     {0x0900, 0x097F},
+	"Thai":                           {0x0E00, 0x0E7F},
+	"HangulJamo":                     {0x1100, 0x11FF},
+	"LatinExtendedAdditional":        {0x1E00, 0x1EFF},
+	"GreekExtended":                  {0x1F00, 0x1FFF},
+	"GeneralPunctuation":             {0x2000, 0x206F},
+	"SuperscriptsandSubscripts":      {0x2070, 0x209F},
+	"CurrencySymbols":                {0x20A0, 0x20CF},
+	"LetterlikeSymbols":              {0x2100, 0x214F},
+	"NumberForms":                    {0x2150, 0x218F},
+	"Arrows":                         {0x2190, 0x21FF},
+	"MathematicalOperators":          {0x2200, 0x22FF},
+	"MiscellaneousTechnical":         {0x2300, 0x23FF},
+	"EnclosedAlphanumerics":          {0x2460, 0x24FF},
+	"BoxDrawing":                     {0x2500, 0x257F},
+	"GeometricShapes":                {0x25A0, 0x25FF},
+	"MiscellaneousSymbols":           {0x2600, 0x26FF},
+	"Dingbats":                       {0x2700, 0x27BF},
+	"CJKSymbolsandPunctuation":       {0x3000, 0x303F},
+	"Hiragana":                       {0x3040, 0x309F},
+	"Katakana":                       {0x30A0, 0x30FF},
+	"CJKUnifiedIdeographsExtensionA": {0x3400, 0x4DB5},
+	"CJKUnifiedIdeographs":           {0x4E00, 0x9FFF},
+	"HangulSyllables":                {0xAC00, 0xD7A3},
+	"PrivateUse":                     {0xE000, 0xF8FF},
+	"CJKCompatibilityIdeographs":     {0xF900, 0xFAFF},
+	"AlphabeticPresentationForms":    {0xFB00, 0xFB4F},
+	"HalfwidthandFullwidthForms":     {0xFF00, 0xFFEF},
+}
+
+// compilePattern compiles the value of the pattern facet to the Go regular
+// expression which matches the whole value, as the XSD patterns are
+// implicitly anchored.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	expr, err := translatePattern(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// translatePattern translates the XSD regular expression to the syntax of the
+// Go regular expressions. The escapes which are not supported by Go or match
+// other characters in Go, the dot and the anchors which are ordinary
+// characters in XSD are translated, and the character class subtraction is
+// computed for the classes of characters and ranges.
+func translatePattern(pattern string) (string, error) {
+	t := &patternTranslator{runes: []rune(pattern)}
+	var b strings.Builder
+	for t.pos < len(t.runes) {
+		r := t.runes[t.pos]
+		t.pos++
+		switch r {
+		case '\\':
+			escape, err := t.escape(false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(escape.outside)
+		case '[':
+			class, _, err := t.class()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(class)
+		case '.':
+			b.WriteString(`[^\n\r]`)
+		case '^', '$':
+			b.WriteString(`\` + string(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
+
+// patternTranslator holds the XSD regular expression being translated and
+// the position of the next character.
+type patternTranslator struct {
+	runes []rune
+	pos   int
+}
+
+// patternEscape is the translated escape of the XSD regular expression. The
+// char is the escaped character of the single character escapes, and the
+// ranges are the characters matched by the escapes of the ranges of
+// characters. The inside and outside are the Go expressions of the escape in
+// and out of a character class.
+type patternEscape struct {
+	char            rune
+	single          bool
+	ranges          []charRange
+	inside, outside string
+}
+
+// charRange is the range of the characters from lo to hi.
+type charRange struct {
+	lo, hi rune
+}
+
+// escape translates the escape after the backslash. The multi-character
+// escapes which would be negated classes can't be used in a character
+// class.
+func (t *patternTranslator) escape(inClass bool) (escape patternEscape, err error) {
+	if t.pos >= len(t.runes) {
+		return escape, errors.New("trailing backslash")
+	}
+	r := t.runes[t.pos]
+	t.pos++
+	negated := func(class string) (patternEscape, error) {
+		if inClass {
+			return patternEscape{}, fmt.Errorf("escape \\%c in character class is not supported", r)
+		}
+		return patternEscape{outside: "[^" + class + "]"}, nil
+	}
+	switch r {
+	case 'n':
+		return singleCharEscape('\n'), nil
+	case 'r':
+		return singleCharEscape('\r'), nil
+	case 't':
+		return singleCharEscape('\t'), nil
+	case '\\', '|', '.', '-', '^', '?', '*', '+', '{', '}', '(', ')', '[', ']':
+		return singleCharEscape(r), nil
+	case 'd':
+		return patternEscape{inside: `\p{Nd}`, outside: `\p{Nd}`}, nil
+	case 'D':
+		return patternEscape{inside: `\P{Nd}`, outside: `\P{Nd}`}, nil
+	case 's':
+		return patternEscape{ranges: []charRange{{' ', ' '}, {'\t', '\t'}, {'\n', '\n'}, {'\r', '\r'}}, inside: ` \t\n\r`, outside: `[ \t\n\r]`}, nil
+	case 'S':
+		return negated(` \t\n\r`)
+	case 'i':
+		return patternEscape{inside: xsdNameStartChars, outside: "[" + xsdNameStartChars + "]"}, nil
+	case 'I':
+		return negated(xsdNameStartChars)
+	case 'c':
+		return patternEscape{inside: xsdNameChars, outside: "[" + xsdNameChars + "]"}, nil
+	case 'C':
+		return negated(xsdNameChars)
+	case 'w':
+		return negated(`\p{P}\p{Z}\p{C}`)
+	case 'W':
+		return patternEscape{inside: `\p{P}\p{Z}\p{C}`, outside: `[\p{P}\p{Z}\p{C}]`}, nil
+	case 'p', 'P':
+		var name string
+		if name, err = t.property(); err != nil {
+			return
+		}
+		if strings.HasPrefix(name, "Is") {
+			block, ok := xsdBlocks[name[2:]]
+			if !ok {
+				return escape, fmt.Errorf("unsupported Unicode block %s", name)
+			}
+			ranges := []charRange{{block[0], block[1]}}
+			class := formatCharRange(ranges[0])
+			if r == 'P' {
+				return negated(class)
+			}
+			return patternEscape{ranges: ranges, inside: class, outside: "[" + class + "]"}, nil
+		}
+		if _, ok := unicode.Categories[name]; !ok {
+			return escape, fmt.Errorf("unknown character category %s", name)
+		}
+		expr := `\` + string(r) + "{" + name + "}"
+		return patternEscape{inside: expr, outside: expr}, nil
+	}
+	return escape, fmt.Errorf("unsupported escape \\%c", r)
+}
+
+// singleCharEscape returns the escape of the single character.
+func singleCharEscape(r rune) patternEscape {
+	return patternEscape{char: r, single: true, inside: formatCharRange(charRange{r, r}), outside: regexp.QuoteMeta(string(r))}
+}
+
+// property returns the name of the character category or block in the braces
+// of the \p and \P escapes.
+func (t *patternTranslator) property() (string, error) {
+	if t.pos >= len(t.runes) || t.runes[t.pos] != '{' {
+		return "", errors.New("missing { after \\p")
+	}
+	for end := t.pos + 1; end < len(t.runes); end++ {
+		if t.runes[end] == '}' {
+			name := string(t.runes[t.pos+1 : end])
+			t.pos = end + 1
+			return name, nil
+		}
+	}
+	return "", errors.New("missing } after \\p")
+}
+
+// class translates the character class after the opening bracket. The ranges
+// of the characters matched by the class are returned if it only holds
+// characters, ranges and the escapes of them, which are required by the
+// class subtraction.
+func (t *patternTranslator) class() (class string, ranges []charRange, err error) {
+	negated := t.pos < len(t.runes) && t.runes[t.pos] == '^'
+	if negated {
+		t.pos++
+	}
+	var body strings.Builder
+	simple := true
+	for {
+		if t.pos >= len(t.runes) {
+			return "", nil, errors.New("missing closing ]")
+		}
+		switch r := t.runes[t.pos]; {
+		case r == ']':
+			t.pos++
+			if body.Len() == 0 {
+				return "", nil, errors.New("empty character class")
+			}
+			if negated {
+				class = "[^" + body.String() + "]"
+			} else {
+				class = "[" + body.String() + "]"
+			}
+			if !simple {
+				return class, nil, nil
+			}
+			if negated {
+				return class, complementCharRanges(ranges), nil
+			}
+			return class, normalizeCharRanges(ranges), nil
+		case r == '-' && t.pos+1 < len(t.runes) && t.runes[t.pos+1] == '[':
+			t.pos += 2
+			var subtracted []charRange
+			if _, subtracted, err = t.class(); err != nil {
+				return
+			}
+			if t.pos >= len(t.runes) || t.runes[t.pos] != ']' {
+				return "", nil, errors.New("missing closing ] after character class subtraction")
+			}
+			t.pos++
+			if !simple || subtracted == nil || body.Len() == 0 {
+				return "", nil, errors.New("character class subtraction is only supported for characters and ranges")
+			}
+			if negated {
+				ranges = complementCharRanges(ranges)
+			}
+			ranges = complementCharRanges(append(complementCharRanges(ranges), subtracted...))
+			if len(ranges) == 0 {
+				// the class doesn't match any character
+				return `[^\x{0}-\x{10FFFF}]`, ranges, nil
+			}
+			var b strings.Builder
+			for _, item := range ranges {
+				b.WriteString(formatCharRange(item))
+			}
+			return "[" + b.String() + "]", ranges, nil
+		}
+		var escape patternEscape
+		if escape, err = t.classChar(); err != nil {
+			return
+		}
+		if !escape.single {
+			if escape.ranges == nil {
+				simple = false
+			}
+			ranges = append(ranges, escape.ranges...)
+			body.WriteString(escape.inside)
+			continue
+		}
+		item := charRange{escape.char, escape.char}
+		if t.pos+1 < len(t.runes) && t.runes[t.pos] == '-' && t.runes[t.pos+1] != ']' && t.runes[t.pos+1] != '[' {
+			t.pos++
+			var hi patternEscape
+			if hi, err = t.classChar(); err != nil {
+				return
+			}
+			if !hi.single || hi.char < escape.char {
+				return "", nil, errors.New("invalid character range")
+			}
+			item.hi = hi.char
+		}
+		ranges = append(ranges, item)
+		body.WriteString(formatCharRange(item))
+	}
+}
+
+// classChar translates the character or escape in a character class.
+func (t *patternTranslator) classChar() (patternEscape, error) {
+	r := t.runes[t.pos]
+	t.pos++
+	switch r {
+	case '\\':
+		return t.escape(true)
+	case '[':
+		return patternEscape{}, errors.New("unescaped [ in character class")
+	}
+	return singleCharEscape(r), nil
+}
+
+// formatCharRange returns the range of the characters in a Go character
+// class, the special and non-printable characters are escaped.
+func formatCharRange(r charRange) string {
+	format := func(r rune) string {
+		if strings.ContainsRune(`\]-^[`, r) {
+			return `\` + string(r)
+		}
+		if !unicode.IsPrint(r) || r > unicode.MaxASCII {
+			return fmt.Sprintf(`\x{%X}`, r)
+		}
+		return string(r)
+	}
+	if r.lo == r.hi {
+		return format(r.lo)
+	}
+	return format(r.lo) + "-" + format(r.hi)
+}
+
+// normalizeCharRanges sorts the ranges and merges the overlapping and
+// adjacent ones.
+func normalizeCharRanges(ranges []charRange) []charRange {
+	sorted := append([]charRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].lo < sorted[j].lo })
+	var merged []charRange
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && r.lo <= merged[n-1].hi+1 {
+			if r.hi > merged[n-1].hi {
+				merged[n-1].hi = r.hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// complementCharRanges returns the ranges of the characters which are not in
+// the given ranges.
+func complementCharRanges(ranges []charRange) []charRange {
+	var complement []charRange
+	lo := rune(0)
+	for _, r := range normalizeCharRanges(ranges) {
+		if r.lo > lo {
+			complement = append(complement, charRange{lo, r.lo - 1})
+		}
+		lo = r.hi + 1
+	}
+	if lo <= unicode.MaxRune {
+		complement = append(complement, charRange{lo, unicode.MaxRune})
+	}
+	return complement
+}
@@ -8,10 +8,15 @@
 
 package xgen
 
-import "regexp"
+import (
+	"encoding/xml"
+	"regexp"
+)
 
 // SimpleType definitions provide for constraining character information item
-// [children] of element and attribute information items.
+// [children] of element and attribute information items. The MemberTypes of
+// a union map the names of the member types to their value types, and the
+// Members hold the names in declaration order.
 // https://www.w3.org/TR/xmlschema-1/#Simple_Type_Definitions
 type SimpleType struct {
 	Doc         string
@@ -21,6 +26,7 @@ type SimpleType struct {
 	List        bool
 	Union       bool
 	MemberTypes map[string]string
+	Members     []string
 	Restriction Restriction
 }
 
@@ -29,31 +35,77 @@ type SimpleType struct {
 // an element information items; Establishing uniquenesses and reference
 // constraint relationships among the values of related elements and
 // attributes; Controlling the substitutability of elements through the
-// mechanism of element substitution groups.
+// mechanism of element substitution groups. The element wildcards declared
+// by <any> have no name, the Namespace and ProcessContents hold the
+// constraint on the namespaces of the matched elements and the validation
+// of them. The SubstitutionGroup is the head element of the substitution
+// group which the top-level element is a member of. The Default holds the
+// value of the default or fixed attribute, and the Fixed reports whether the
+// value is fixed. The Alternatives are the conditional type assignments of
+// XSD 1.1 declared in the element, and the IdentityConstraints are the key,
+// keyref and unique constraints declared in it. The TargetNamespace is the
+// namespace of the element name in the instance documents, which is empty for
+// the local elements unqualified by the form attribute or the
+// elementFormDefault of the schema. The RefElement, ComplexTypeDef and
+// SimpleTypeDef are the referenced element and the type of the element
+// resolved in the schema model, which are nil for the built-in types and the
+// components which can't be found.
 // https://www.w3.org/TR/xmlschema-1/#cElement_Declarations
 type Element struct {
-	Doc      string
-	Name     string
-	Wildcard bool
-	Type     string
-	Abstract bool
-	Plural   bool
-	Optional bool
-	Nillable bool
-	Default  string
+	Doc                 string
+	Name                string
+	Ref                 xml.Name
+	Wildcard            bool
+	Namespace           string
+	ProcessContents     string
+	TargetNamespace     string
+	Type                string
+	TypeName            xml.Name
+	Abstract            bool
+	SubstitutionGroup   xml.Name
+	Plural              bool
+	Optional            bool
+	Nillable            bool
+	Default             string
+	Fixed               bool
+	Alternatives        []Alternative
+	IdentityConstraints []IdentityConstraint
+	Restriction         Restriction
+	RefElement          *Element
+	ComplexTypeDef      *ComplexType
+	SimpleTypeDef       *SimpleType
 }
 
 // Attribute declarations provide for: Local validation of attribute
 // information item values using a simple type definition; Specifying default
-// or fixed values for attribute information items.
+// or fixed values for attribute information items. The Default holds the
+// value of the default or fixed attribute, and the Fixed reports whether the
+// value is fixed. The attribute wildcards
+// declared by <anyAttribute> have no name, like the element wildcards. The
+// prohibited attributes remove the inherited attributes from the complex
+// types derived by restriction. The TargetNamespace is the namespace of the
+// attribute name, like the one of the elements by the attributeFormDefault.
+// The RefAttribute and SimpleTypeDef are the referenced attribute and the
+// type of the attribute resolved in the schema model.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-attribute
 type Attribute struct {
-	Name     string
-	Doc      string
-	Type     string
-	Plural   bool
-	Default  string
-	Optional bool
+	Name            string
+	Ref             xml.Name
+	Wildcard        bool
+	Namespace       string
+	ProcessContents string
+	TargetNamespace string
+	Doc             string
+	Type            string
+	TypeName        xml.Name
+	Plural          bool
+	Default         string
+	Fixed           bool
+	Optional        bool
+	Prohibited      bool
+	Restriction     Restriction
+	RefAttribute    *Attribute
+	SimpleTypeDef   *SimpleType
 }
 
 // ComplexType definitions are identified by their {name} and {target
@@ -65,25 +117,81 @@ type Attribute struct {
 // namespace}s are provided for reference from instances, and for use in the
 // XML representation of schema components (specifically in <element>). See
 // References to schema components across namespaces for the use of component
-// identifiers when importing one schema into another.
+// identifiers when importing one schema into another. The Derivation is
+// the method, extension or restriction, by which the type is derived from the
+// base type, and the SimpleContent reports whether the type has a character
+// data value instead of the child elements. The anonymous complex type of a
+// top-level element represents the element, which is reported by the
+// Element, and holds the IdentityConstraints declared in it. The Anonymous
+// reports whether the type is declared in an element without a name, the
+// anonymous types of the local elements are named by the path of the element
+// like OrderLineItemPrice. The Assertions are the assertions of XSD 1.1 on
+// the elements and attributes of the type. The BaseComplexType and
+// BaseSimpleType are the base type resolved in the schema model.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-complexType
 type ComplexType struct {
-	Doc            string
-	Name           string
-	Base           string
-	Anonymous      bool
-	Elements       []Element
-	Attributes     []Attribute
-	Groups         []Group
-	Choice         []Choice
-	AttributeGroup []AttributeGroup
-	Mixed          bool
+	Doc                 string
+	Name                string
+	Base                string
+	BaseName            xml.Name
+	Derivation          string
+	SimpleContent       bool
+	Anonymous           bool
+	Element             bool
+	Elements            []Element
+	Attributes          []Attribute
+	Groups              []Group
+	Choice              []Choice
+	AttributeGroup      []AttributeGroup
+	Assertions          []Assertion
+	IdentityConstraints []IdentityConstraint
+	Mixed               bool
+	BaseComplexType     *ComplexType
+	BaseSimpleType      *SimpleType
+}
+
+// IdentityConstraint definitions are the key, keyref and unique constraints,
+// which assert the uniqueness of the values of the fields among the elements
+// selected by the selector, or the references of them to the values of a key
+// or unique constraint. The Kind is the name of the declaring element, and
+// the Refer is the key or unique constraint referenced by the keyref. The
+// Selector and Fields are the XPath expressions of the restricted subset
+// defined by the specification.
+// https://www.w3.org/TR/xmlschema-1/structures.html#cIdentity-constraint_Definitions
+type IdentityConstraint struct {
+	Kind     string
+	Name     string
+	Refer    xml.Name
+	Selector string
+	Fields   []string
+}
+
+// Assertion definitions are the assertions of XSD 1.1, which constrain the
+// complex types by the assert elements, and the simple types by the
+// assertion facets. The Test is the XPath expression which must be true for
+// the valid values.
+// https://www.w3.org/TR/xmlschema11-1/#cAssertions
+type Assertion struct {
+	Test                  string
+	XPathDefaultNamespace string
+}
+
+// Alternative definitions are the type alternatives of XSD 1.1, which select
+// the type of the element by the XPath expression on its attributes. The
+// first alternative whose Test is true is selected, and the alternative
+// without the Test is the default one.
+// https://www.w3.org/TR/xmlschema11-1/#cTypeAlternative
+type Alternative struct {
+	Test     string
+	Type     string
+	TypeName xml.Name
 }
 
 // Group (model group) definitions are provided primarily for reference from
 // the XML Representation of Complex Type Definitions. Thus, model group
 // definitions provide a replacement for some uses of XML's parameter entity
-// facility.
+// facility. The RefGroup is the referenced group resolved in the schema
+// model.
 // https://www.w3.org/TR/xmlschema-1/structures.html#cModel_Group_Definitions
 type Group struct {
 	Doc      string
@@ -92,6 +200,8 @@ type Group struct {
 	Groups   []Group
 	Plural   bool
 	Ref      string
+	RefName  xml.Name
+	RefGroup *Group
 }
 
 // Choice definitions are provided primarily for reference from
@@ -114,23 +224,38 @@ type Choice struct {
 // for some uses of XML's parameter entity facility. Attribute group
 // definitions are provided primarily for reference from the XML
 // representation of schema components (see <complexType> and
-// <attributeGroup>).
+// <attributeGroup>). The RefAttributeGroup is the referenced attribute group
+// resolved in the schema model.
 // https://www.w3.org/TR/xmlschema-1/structures.html#Attribute_Group_Definition
 type AttributeGroup struct {
-	Doc        string
-	Name       string
-	Ref        string
-	Attributes []Attribute
+	Doc               string
+	Name              string
+	Ref               string
+	RefName           xml.Name
+	Attributes        []Attribute
+	AttributeGroup    []AttributeGroup
+	RefAttributeGroup *AttributeGroup
 }
 
 // Restriction are used to define acceptable values for XML elements or
-// attributes. Restriction on XML elements are called facets.
+// attributes. Restriction on XML elements are called facets. The HasMin,
+// HasMax, HasMinLength and HasMaxLength report whether the corresponding
+// facets are specified, the Min and Max are the lexical values of the bounds,
+// and the MinExclusive and MaxExclusive report whether the bounds are
+// exclusive. The Patterns are the pattern facets of each
+// derivation step, the valid values match all of them. The Assertions are
+// the assertion facets of XSD 1.1.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-restriction
 type Restriction struct {
-	Doc                  string
-	Precision            int
-	Enum                 []string
-	Min, Max             float64
-	MinLength, MaxLength int
-	Pattern              *regexp.Regexp
+	Doc                        string
+	Precision                  int
+	TotalDigits                int
+	Enum                       []string
+	Min, Max                   string
+	HasMin, HasMax             bool
+	MinExclusive, MaxExclusive bool
+	MinLength, MaxLength       int
+	HasMinLength, HasMaxLength bool
+	Patterns                   []*regexp.Regexp
+	Assertions                 []Assertion
 }
@@ -0,0 +1,139 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// resolveRedefinitions resolves the components in the proto tree, which are
+// redefined in terms of themselves in the redefine elements, with the
+// original components in the included schemas. The complex types extend or
+// restrict the original types, and the groups and attribute groups reference
+// the original groups. The attribute groups referenced in the attribute
+// groups are merged into them.
+func (opt *Options) resolveRedefinitions() {
+	resolved := make(map[*AttributeGroup]bool)
+	for _, ele := range opt.ProtoTree {
+		switch v := ele.(type) {
+		case *ComplexType:
+			opt.Schema.redefineComplexType(v)
+		case *Group:
+			opt.Schema.redefineGroup(v)
+		case *AttributeGroup:
+			opt.Schema.resolveAttributeGroup(v, resolved)
+		}
+	}
+}
+
+// included calls find with the given name for the schemas included by the
+// schema, until the component is found. The original components of the
+// redefinitions are only looked up in the included schemas, since the
+// redefinitions replace them in the schema.
+func (s *Schema) included(name xml.Name, find func(*Schema, xml.Name) bool) {
+	for _, schema := range s.Includes {
+		var found bool
+		schema.lookup(name, func(schema *Schema, name xml.Name) bool {
+			found = find(schema, name)
+			return found
+		})
+		if found {
+			return
+		}
+	}
+}
+
+// redefineComplexType merges the original complex type into the complex
+// type derived from it. The extension appends the particles and attributes
+// to the original ones and takes the base type of the original type, and the
+// restriction inherits the attributes of the original type.
+func (s *Schema) redefineComplexType(v *ComplexType) {
+	if v.BaseName.Local == "" || !s.refers(v.BaseName, v.Name) {
+		return
+	}
+	var original *ComplexType
+	s.included(s.name(v.Name), func(schema *Schema, name xml.Name) bool {
+		original = schema.ComplexTypes[name]
+		return original != nil
+	})
+	if original == nil {
+		return
+	}
+	if v.Derivation == "extension" {
+		v.Elements = append(append([]Element{}, original.Elements...), v.Elements...)
+		v.Groups = append(append([]Group{}, original.Groups...), v.Groups...)
+		v.Choice = append(append([]Choice{}, original.Choice...), v.Choice...)
+		v.Attributes = mergeAttributes(original.Attributes, v.Attributes)
+		v.AttributeGroup = mergeAttributeGroups(original.AttributeGroup, v.AttributeGroup)
+		v.Base, v.BaseName, v.Derivation, v.SimpleContent = original.Base, original.BaseName, original.Derivation, original.SimpleContent
+		return
+	}
+	attributes, attributeGroups := s.attributeUses(original, map[*ComplexType]bool{})
+	v.Attributes = mergeAttributes(attributes, v.Attributes)
+	v.AttributeGroup = mergeAttributeGroups(attributeGroups, v.AttributeGroup)
+	v.Base, v.BaseName, v.Derivation = "", xml.Name{}, ""
+	if v.SimpleContent {
+		if v.Base = s.valueType(original, map[*ComplexType]bool{}); v.Base == "" {
+			v.Base = original.Base
+		}
+	}
+}
+
+// redefineGroup replaces the reference to the original group in the group
+// with the particles of the original group.
+func (s *Schema) redefineGroup(v *Group) {
+	name := s.name(v.Name)
+	for i, group := range v.Groups {
+		if group.RefName.Local == "" || !s.refers(group.RefName, v.Name) {
+			continue
+		}
+		var original *Group
+		s.included(name, func(schema *Schema, name xml.Name) bool {
+			original = schema.Groups[name]
+			return original != nil
+		})
+		if original == nil {
+			return
+		}
+		groups := append(append([]Group{}, v.Groups[:i]...), original.Groups...)
+		v.Groups = append(groups, v.Groups[i+1:]...)
+		v.Elements = append(append([]Element{}, original.Elements...), v.Elements...)
+		return
+	}
+}
+
+// resolveAttributeGroup merges the attributes of the attribute groups
+// referenced in the attribute group into it. The reference to the attribute
+// group itself is resolved to the original attribute group in the included
+// schemas. The references which can't be resolved are kept.
+func (s *Schema) resolveAttributeGroup(v *AttributeGroup, resolved map[*AttributeGroup]bool) {
+	if resolved[v] {
+		return
+	}
+	resolved[v] = true
+	name := s.name(v.Name)
+	var attributes []Attribute
+	var unresolved []AttributeGroup
+	for _, attrGroup := range v.AttributeGroup {
+		var ref *AttributeGroup
+		if s.refers(attrGroup.RefName, v.Name) {
+			s.included(name, func(schema *Schema, name xml.Name) bool {
+				ref = schema.AttributeGroups[name]
+				return ref != nil
+			})
+		} else if ref = s.AttributeGroup(attrGroup.RefName); ref != nil && s.AttributeGroups[s.name(ref.Name)] == ref {
+			s.resolveAttributeGroup(ref, resolved)
+		}
+		if ref == nil {
+			unresolved = append(unresolved, attrGroup)
+			continue
+		}
+		attributes = mergeAttributes(attributes, ref.Attributes)
+	}
+	v.Attributes = mergeAttributes(attributes, v.Attributes)
+	v.AttributeGroup = unresolved
+}
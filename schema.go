@@ -8,7 +8,11 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"errors"
+	"io/fs"
+)
 
 func (opt *Options) prepareLocalNameNSMap(element xml.StartElement) {
 	for _, ele := range element.Attr {
@@ -28,14 +32,6 @@ func (opt *Options) prepareNSSchemaLocationMap(element xml.StartElement) {
 			if _, ok := opt.NSSchemaLocationMap[currentNS]; ok {
 				continue
 			}
-			if isValidURL(ele.Value) {
-				continue
-				// TODO: fetch remote schema
-				// var err error
-				// if opt.RemoteSchema[ele.Value], err = fetchSchema(ele.Value); err != nil {
-				// 	continue
-				// }
-			}
 			opt.NSSchemaLocationMap[currentNS] = ele.Value
 		}
 	}
@@ -44,3 +40,412 @@ func (opt *Options) prepareNSSchemaLocationMap(element xml.StartElement) {
 func (opt *Options) parseNS(str string) (ns string) {
 	return opt.LocalNameNSMap[getNSPrefix(str)]
 }
+
+// Schema is the typed model of an XML schema document. The top-level schema
+// components are indexed by their qualified names in the target namespace,
+// and the imported and included schemas are linked by pointers, so that the
+// references across files can be resolved with the lookup methods, such as
+// ComplexType and SimpleType. The references of the components in the
+// document are resolved to the pointer fields of them, such as the
+// ComplexTypeDef of the elements. The proto tree of the document is kept in
+// ProtoTree, which the code generators iterate to generate the types in the
+// declaration order.
+type Schema struct {
+	FilePath             string
+	TargetNamespace      string
+	ElementFormDefault   string
+	AttributeFormDefault string
+	Imports              []*Schema
+	Includes             []*Schema
+	SimpleTypes          map[xml.Name]*SimpleType
+	ComplexTypes         map[xml.Name]*ComplexType
+	Elements             map[xml.Name]*Element
+	Attributes           map[xml.Name]*Attribute
+	Groups               map[xml.Name]*Group
+	AttributeGroups      map[xml.Name]*AttributeGroup
+	ProtoTree            []interface{}
+
+	namespaces map[string]string
+	refs       []schemaRef
+	generated  map[string][]byte
+	symbols    *symbolTable
+}
+
+// schemaRef is an <import> or <include> statement of the schema.
+type schemaRef struct {
+	namespace string
+	location  string
+	include   bool
+}
+
+// newSchema creates an empty schema model for the schema file. Each parsing
+// of the schema file creates a new model, the model isn't modified once the
+// parsing is done, so it could be shared by the parsers.
+func newSchema(filePath string) *Schema {
+	return &Schema{
+		FilePath:             filePath,
+		ElementFormDefault:   "unqualified",
+		AttributeFormDefault: "unqualified",
+		SimpleTypes:          make(map[xml.Name]*SimpleType),
+		ComplexTypes:         make(map[xml.Name]*ComplexType),
+		Elements:             make(map[xml.Name]*Element),
+		Attributes:           make(map[xml.Name]*Attribute),
+		Groups:               make(map[xml.Name]*Group),
+		AttributeGroups:      make(map[xml.Name]*AttributeGroup),
+		namespaces:           make(map[string]string),
+	}
+}
+
+// index adds the top-level components in the proto tree to the schema.
+func (s *Schema) index(protoTree []interface{}) {
+	s.ProtoTree = protoTree
+	for _, ele := range protoTree {
+		switch v := ele.(type) {
+		case *SimpleType:
+			s.SimpleTypes[s.name(v.Name)] = v
+		case *ComplexType:
+			s.ComplexTypes[s.name(v.Name)] = v
+		case *Element:
+			s.Elements[s.name(v.Name)] = v
+		case *Attribute:
+			s.Attributes[s.name(v.Name)] = v
+		case *Group:
+			s.Groups[s.name(v.Name)] = v
+		case *AttributeGroup:
+			s.AttributeGroups[s.name(v.Name)] = v
+		}
+	}
+}
+
+// resolve links the references of the components in the proto tree to the
+// components of the schema model: the types of the elements and attributes,
+// the referenced elements, attributes, groups and attribute groups, and the
+// base types of the complex types. The references to the built-in types and
+// the components which can't be found are left nil.
+func (s *Schema) resolve(protoTree []interface{}) {
+	for _, ele := range protoTree {
+		switch v := ele.(type) {
+		case *Element:
+			s.resolveElement(v)
+		case *Attribute:
+			s.resolveAttribute(v)
+		case *ComplexType:
+			if v.BaseName.Local != "" {
+				v.BaseComplexType, v.BaseSimpleType = s.ComplexType(v.BaseName), s.SimpleType(v.BaseName)
+			}
+			s.resolveParticles(v.Elements, v.Groups)
+			s.resolveAttributes(v.Attributes, v.AttributeGroup)
+		case *Group:
+			s.resolveParticles(v.Elements, v.Groups)
+		case *AttributeGroup:
+			s.resolveAttributes(v.Attributes, v.AttributeGroup)
+		}
+	}
+}
+
+// resolveElement links the referenced element and the type of the element.
+// The anonymous complex types of the local elements are found by the names
+// derived for them.
+func (s *Schema) resolveElement(e *Element) {
+	if e.Wildcard {
+		return
+	}
+	if e.Ref.Local != "" {
+		e.RefElement = s.Element(e.Ref)
+	}
+	if e.TypeName.Local != "" {
+		e.ComplexTypeDef, e.SimpleTypeDef = s.ComplexType(e.TypeName), s.SimpleType(e.TypeName)
+		return
+	}
+	if v := s.ComplexTypes[s.name(e.Type)]; v != nil && v.Anonymous {
+		e.ComplexTypeDef = v
+	}
+}
+
+// resolveAttribute links the referenced attribute and the type of the
+// attribute.
+func (s *Schema) resolveAttribute(a *Attribute) {
+	if a.Wildcard {
+		return
+	}
+	if a.Ref.Local != "" {
+		a.RefAttribute = s.Attribute(a.Ref)
+	}
+	if a.TypeName.Local != "" {
+		a.SimpleTypeDef = s.SimpleType(a.TypeName)
+	}
+}
+
+// resolveParticles links the references of the elements and groups of a
+// complex type or group.
+func (s *Schema) resolveParticles(elements []Element, groups []Group) {
+	for i := range elements {
+		s.resolveElement(&elements[i])
+	}
+	for i := range groups {
+		if groups[i].RefName.Local != "" {
+			groups[i].RefGroup = s.Group(groups[i].RefName)
+		}
+	}
+}
+
+// resolveAttributes links the references of the attributes and attribute
+// groups of a complex type or attribute group.
+func (s *Schema) resolveAttributes(attributes []Attribute, attributeGroups []AttributeGroup) {
+	for i := range attributes {
+		s.resolveAttribute(&attributes[i])
+	}
+	for i := range attributeGroups {
+		if attributeGroups[i].RefName.Local != "" {
+			attributeGroups[i].RefAttributeGroup = s.AttributeGroup(attributeGroups[i].RefName)
+		}
+	}
+}
+
+// name returns the qualified name of the component declared in the schema.
+func (s *Schema) name(local string) xml.Name {
+	return xml.Name{Space: s.TargetNamespace, Local: trimNSPrefix(local)}
+}
+
+// refers reports whether the qualified name refers to the component with the
+// given name declared in the schema. The components of the schemas without
+// target namespace are referred by the local name, like the lookups.
+func (s *Schema) refers(ref xml.Name, local string) bool {
+	name := s.name(local)
+	return ref == name || (s.TargetNamespace == "" && ref.Local == name.Local)
+}
+
+// walk calls fn for the schema, and its imported and included schemas
+// recursively, until fn returns true.
+func (s *Schema) walk(fn func(*Schema) bool, visited map[*Schema]bool) bool {
+	if s == nil || visited[s] {
+		return false
+	}
+	visited[s] = true
+	if fn(s) {
+		return true
+	}
+	for _, schemas := range [][]*Schema{s.Includes, s.Imports} {
+		for _, schema := range schemas {
+			if schema.walk(fn, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookup calls find with the given name for the schema, and its imported and
+// included schemas, until the component is found. The components of the
+// schemas without target namespace are also found by the local name, as they
+// take the namespace of the including schema.
+func (s *Schema) lookup(name xml.Name, find func(*Schema, xml.Name) bool) {
+	s.walk(func(schema *Schema) bool {
+		if find(schema, name) {
+			return true
+		}
+		return schema.TargetNamespace == "" && name.Space != "" && find(schema, xml.Name{Local: name.Local})
+	}, map[*Schema]bool{})
+}
+
+// SimpleType returns the simple type with the given qualified name declared
+// in the schema or the schemas it references, or nil if it doesn't exist.
+func (s *Schema) SimpleType(name xml.Name) (v *SimpleType) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.SimpleTypes[name]
+		return v != nil
+	})
+	return
+}
+
+// ComplexType returns the complex type with the given qualified name declared
+// in the schema or the schemas it references, or nil if it doesn't exist.
+func (s *Schema) ComplexType(name xml.Name) (v *ComplexType) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.ComplexTypes[name]
+		return v != nil
+	})
+	return
+}
+
+// Element returns the top-level element with the given qualified name
+// declared in the schema or the schemas it references, or nil if it doesn't
+// exist.
+func (s *Schema) Element(name xml.Name) (v *Element) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.Elements[name]
+		return v != nil
+	})
+	return
+}
+
+// Attribute returns the top-level attribute with the given qualified name
+// declared in the schema or the schemas it references, or nil if it doesn't
+// exist.
+func (s *Schema) Attribute(name xml.Name) (v *Attribute) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.Attributes[name]
+		return v != nil
+	})
+	return
+}
+
+// Group returns the group with the given qualified name declared in the
+// schema or the schemas it references, or nil if it doesn't exist.
+func (s *Schema) Group(name xml.Name) (v *Group) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.Groups[name]
+		return v != nil
+	})
+	return
+}
+
+// AttributeGroup returns the attribute group with the given qualified name
+// declared in the schema or the schemas it references, or nil if it doesn't
+// exist.
+func (s *Schema) AttributeGroup(name xml.Name) (v *AttributeGroup) {
+	s.lookup(name, func(schema *Schema, name xml.Name) bool {
+		v = schema.AttributeGroups[name]
+		return v != nil
+	})
+	return
+}
+
+// Substitutes returns the members of the substitution group headed by the
+// element with the given qualified name, and the members of their
+// substitution groups, declared in the schema or the schemas it references,
+// in the declaration order.
+func (s *Schema) Substitutes(head xml.Name) (members []*Element) {
+	heads := map[xml.Name]bool{}
+	var collect func(head xml.Name)
+	collect = func(head xml.Name) {
+		if heads[head] {
+			return
+		}
+		heads[head] = true
+		s.walk(func(schema *Schema) bool {
+			for _, ele := range schema.ProtoTree {
+				v, ok := ele.(*Element)
+				if !ok || (v.SubstitutionGroup != head && (schema.TargetNamespace != "" || v.SubstitutionGroup.Local != head.Local)) {
+					continue
+				}
+				members = append(members, v)
+				collect(schema.name(v.Name))
+			}
+			return false
+		}, map[*Schema]bool{})
+	}
+	collect(head)
+	return
+}
+
+// substitutionGroup returns the elements which could appear in place of the
+// referenced element: the head element unless it's abstract, and the members
+// of its substitution group. It returns nil if the element doesn't reference
+// the head of a substitution group.
+func (gen *CodeGenerator) substitutionGroup(element *Element) []*Element {
+	if gen.Schema == nil || element.Ref.Local == "" {
+		return nil
+	}
+	members := gen.Schema.Substitutes(element.Ref)
+	if len(members) == 0 {
+		return nil
+	}
+	if head := element.RefElement; head != nil && !head.Abstract {
+		members = append([]*Element{head}, members...)
+	}
+	return members
+}
+
+// alternativeDeclaration returns the declaration of the element which has
+// the type alternatives, which is the element itself or the referenced
+// top-level element. It returns nil for the elements without them.
+func (gen *CodeGenerator) alternativeDeclaration(element *Element) *Element {
+	if len(element.Alternatives) > 0 {
+		return element
+	}
+	if decl := element.RefElement; decl != nil && len(decl.Alternatives) > 0 {
+		return decl
+	}
+	return nil
+}
+
+// prefix returns the prefix declared for the namespace in the schema
+// document, the first one in the lexical order if there are many. It returns
+// an empty string if the namespace is only declared as the default one.
+func (s *Schema) prefix(namespace string) (prefix string) {
+	for p, ns := range s.namespaces {
+		if ns == namespace && p != "" && (prefix == "" || p < prefix) {
+			prefix = p
+		}
+	}
+	return
+}
+
+// namespace returns the namespace of the name of the element or attribute
+// declaration in the instance documents. The top-level declarations and the
+// references to them are qualified, and the local declarations are qualified
+// by the form attribute, or the form default of the schema without it.
+func (opt *Options) namespace(ref xml.Name, form, formDefault string, local bool) string {
+	switch {
+	case ref.Local != "":
+		return ref.Space
+	case !local, form == "qualified", form == "" && formDefault == "qualified":
+		return opt.Schema.TargetNamespace
+	}
+	return ""
+}
+
+// qualifiedName resolves the namespace prefix of the QName value in the
+// schema document, such as the value of the type, ref and base attributes.
+func (opt *Options) qualifiedName(value string) xml.Name {
+	return xml.Name{Space: opt.Schema.namespaces[getNSPrefix(value)], Local: trimNSPrefix(value)}
+}
+
+// linkSchemas links the schemas in the <import> and <include> statements of
+// the current schema document. The schemas which aren't parsed yet are parsed
+// without generating code. The schemas which can't be located are left
+// unlinked, the errors are reported when the types in them are resolved. The
+// errors of parsing the located schemas are returned as ResolveError.
+func (opt *Options) linkSchemas() error {
+	for _, ref := range opt.Schema.refs {
+		file, cataloged := opt.locateSchema(ref.namespace, ref.location)
+		if ref.location == "" && !cataloged {
+			continue
+		}
+		if isValidURL(file) && opt.Fetcher == nil {
+			continue
+		}
+		schema, ok := opt.SchemaMap[file]
+		if !ok {
+			schema, ok = opt.Cache.load(opt.schemaCacheKey(file, true))
+		}
+		if !ok {
+			schema, ok = opt.Cache.load(opt.schemaCacheKey(file, false))
+		}
+		if !ok {
+			// the maps of the statements are isolated, the parser only
+			// collects the schema components.
+			parser := opt.subParser(file, true)
+			parser.IncludeMap = make(map[string]bool)
+			parser.LocalNameNSMap = make(map[string]string)
+			parser.NSSchemaLocationMap = make(map[string]string)
+			if err := parser.Parse(); err != nil {
+				if errors.Is(err, fs.ErrNotExist) || errors.Is(err, ErrOffline) {
+					continue
+				}
+				return &ResolveError{Location: file, Err: err}
+			}
+			schema = parser.Schema
+		}
+		if schema == nil {
+			continue
+		}
+		if ref.include {
+			opt.Schema.Includes = append(opt.Schema.Includes, schema)
+			continue
+		}
+		opt.Schema.Imports = append(opt.Schema.Imports, schema)
+	}
+	return nil
+}
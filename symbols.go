@@ -0,0 +1,198 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+// symbolTable indexes the top-level components of a proto tree by name, to
+// answer the type lookups in constant time instead of scanning the proto
+// tree. The proto trees are append-only, so the table of the proto tree being
+// parsed is updated incrementally with the components appended since the
+// last lookup. Each table is owned by the parser, schema or code generator of
+// the proto tree, and is released with it.
+type symbolTable struct {
+	indexed     int
+	bases       map[string]symbol
+	simpleTypes map[string]symbol
+}
+
+// symbol is the first component with a name in the proto tree, and its
+// position in the proto tree.
+type symbol struct {
+	pos   int
+	value interface{}
+}
+
+// update adds the components appended to the proto tree of the table since
+// the last update, and returns the table.
+func (t *symbolTable) update(protoTree []interface{}) *symbolTable {
+	if t.bases == nil {
+		t.bases, t.simpleTypes = make(map[string]symbol), make(map[string]symbol)
+	}
+	for ; t.indexed < len(protoTree); t.indexed++ {
+		t.add(t.indexed, protoTree[t.indexed])
+	}
+	return t
+}
+
+// sameProtoTree reports whether the proto tree is the given owner proto tree
+// or the part of it before the components appended later.
+func sameProtoTree(protoTree, owner []interface{}) bool {
+	return len(protoTree) <= len(owner) && (len(protoTree) == 0 || &protoTree[0] == &owner[0])
+}
+
+// add adds the component at the position of the proto tree to the table, the
+// first component with the name is kept like the linear lookups.
+func (t *symbolTable) add(pos int, ele interface{}) {
+	var name string
+	switch v := ele.(type) {
+	case *SimpleType:
+		if _, ok := t.simpleTypes[v.Name]; !ok {
+			t.simpleTypes[v.Name] = symbol{pos: pos, value: v}
+		}
+		if v.List || v.Union {
+			return
+		}
+		name = v.Name
+	case *Attribute:
+		name = v.Name
+	case *Element:
+		name = v.Name
+	default:
+		return
+	}
+	if _, ok := t.bases[name]; !ok {
+		t.bases[name] = symbol{pos: pos, value: ele}
+	}
+}
+
+// baseType returns the same result as getBasefromSimpleType for the proto
+// tree with the given length.
+func (t *symbolTable) baseType(name string, length int) string {
+	s, ok := t.bases[name]
+	if !ok || s.pos >= length {
+		return name
+	}
+	switch v := s.value.(type) {
+	case *SimpleType:
+		return v.Base
+	case *Attribute:
+		return v.Type
+	case *Element:
+		return v.Type
+	}
+	return name
+}
+
+// simpleType returns the same result as getSimpleType for the proto tree with
+// the given length.
+func (t *symbolTable) simpleType(name string, length int) *SimpleType {
+	if s, ok := t.simpleTypes[name]; ok && s.pos < length {
+		return s.value.(*SimpleType)
+	}
+	return nil
+}
+
+// baseType returns the base type of the named simple type, attribute or
+// element declared in the proto tree. The proto tree being parsed is looked up
+// in the symbol table of the parser, and the other proto trees are scanned.
+func (opt *Options) baseType(name string, protoTree []interface{}) string {
+	if opt.symbols == nil || !sameProtoTree(protoTree, opt.ProtoTree) {
+		return getBasefromSimpleType(name, protoTree)
+	}
+	return opt.symbols.update(opt.ProtoTree).baseType(name, len(protoTree))
+}
+
+// simpleType returns the named simple type declared in the proto tree, or nil
+// if it doesn't exist. The proto tree being parsed is looked up in the symbol
+// table of the parser, and the other proto trees are scanned.
+func (opt *Options) simpleType(name string, protoTree []interface{}) *SimpleType {
+	if opt.symbols == nil || !sameProtoTree(protoTree, opt.ProtoTree) {
+		return getSimpleType(name, protoTree)
+	}
+	return opt.symbols.update(opt.ProtoTree).simpleType(name, len(protoTree))
+}
+
+// baseType returns the base type of the named simple type, attribute or
+// element declared in the proto tree of the parsed schema, which is looked up
+// in the symbol table of the schema.
+func (s *Schema) baseType(name string, protoTree []interface{}) string {
+	if s == nil || s.symbols == nil || !sameProtoTree(protoTree, s.ProtoTree) {
+		return getBasefromSimpleType(name, protoTree)
+	}
+	return s.symbols.baseType(name, len(protoTree))
+}
+
+// baseType returns the base type of the named simple type, attribute or
+// element declared in the proto tree of the code generator.
+func (gen *CodeGenerator) baseType(name string) string {
+	return gen.symbols.update(gen.ProtoTree).baseType(name, len(gen.ProtoTree))
+}
+
+// simpleType returns the named simple type declared in the proto tree of the
+// code generator, or nil if it doesn't exist.
+func (gen *CodeGenerator) simpleType(name string) *SimpleType {
+	return gen.symbols.update(gen.ProtoTree).simpleType(name, len(gen.ProtoTree))
+}
+
+// references returns the names of the complex types and groups referenced by
+// the elements, groups and base types of the named complex type or group of
+// the proto tree of the code generator.
+func (gen *CodeGenerator) references(name string) (names []string) {
+	if gen.typeReferences == nil {
+		gen.typeReferences = make(map[string][]string)
+		add := func(name string, elements []Element, groups []Group) {
+			refs := gen.typeReferences[name]
+			for _, element := range elements {
+				if !element.Wildcard {
+					refs = append(refs, gen.baseType(trimNSPrefix(element.Type)))
+				}
+			}
+			for _, group := range groups {
+				refs = append(refs, trimNSPrefix(group.Ref))
+			}
+			gen.typeReferences[name] = refs
+		}
+		for _, ele := range gen.ProtoTree {
+			switch v := ele.(type) {
+			case *ComplexType:
+				add(v.Name, v.Elements, v.Groups)
+				if v.Base != "" {
+					gen.typeReferences[v.Name] = append(gen.typeReferences[v.Name], gen.baseType(trimNSPrefix(v.Base)))
+				}
+			case *Group:
+				add(v.Name, v.Elements, v.Groups)
+			}
+		}
+	}
+	return gen.typeReferences[name]
+}
+
+// recursiveType reports whether the named complex type or group references
+// the owner type directly or indirectly, so a field of the type in the owner
+// type makes the type recursive. The fields of the recursive types need the
+// indirection in the languages which embed the values of the structs.
+func (gen *CodeGenerator) recursiveType(owner, name string) bool {
+	visited := map[string]bool{}
+	var reaches func(name string) bool
+	reaches = func(name string) bool {
+		if name == owner {
+			return true
+		}
+		if visited[name] {
+			return false
+		}
+		visited[name] = true
+		for _, ref := range gen.references(name) {
+			if reaches(ref) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(name)
+}
@@ -0,0 +1,30 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// SeatClass ...
+typedef char SeatClass;
+
+// AuditAttrs ...
+typedef struct {
+	char SourceAttr; // attr, optional
+	int RevisionAttr; // attr, optional
+} AuditAttrs;
+
+// Seating ...
+typedef struct {
+	char Seat;
+} Seating;
+
+// Ticket ...
+typedef struct {
+	AuditAttrs TnsAuditAttrs;
+	char LangAttr; // attr, optional
+	float VersionAttr; // attr, optional
+	char ClassAttr; // attr, optional
+	Seating TnsSeating;
+	char Holder;
+	int Quantity;
+	float Price;
+	bool Refundable;
+	char Currency;
+	char Stamp;
+} Ticket;
@@ -0,0 +1,54 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// Amount ...
+typedef float Amount;
+
+// PositiveAmount ...
+typedef float PositiveAmount;
+
+// Price ...
+typedef struct {
+	char CurrencyAttr; // attr
+	char NoteAttr; // attr, optional
+} Price;
+
+// LocalPrice ...
+typedef struct {
+	char CurrencyAttr; // attr
+} LocalPrice;
+
+// TaxedPrice ...
+typedef struct {
+	float TaxAttr; // attr, optional
+} TaxedPrice;
+
+// NetPrice ...
+typedef struct {
+	char CurrencyAttr; // attr
+	char NoteAttr; // attr, optional
+	float TaxAttr; // attr
+} NetPrice;
+
+// Fee ...
+typedef struct {
+	char CurrencyAttr; // attr
+} Fee;
+
+// SmallFee ...
+typedef struct {
+	char CurrencyAttr; // attr
+} SmallFee;
+
+// Item ...
+typedef struct {
+	char SkuAttr; // attr
+	char Name;
+	Price Price;
+	char Comment;
+} Item;
+
+// Gift ...
+typedef struct {
+	char SkuAttr; // attr
+	char Name;
+} Gift;
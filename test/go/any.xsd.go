@@ -0,0 +1,37 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ExtensionAttributes ...
+type ExtensionAttributes struct {
+	XMLName     xml.Name   `xml:"extensionAttributes"`
+	VersionAttr *string    `xml:"version,attr,omitempty"`
+	AnyAttrs    []xml.Attr `xml:",any,attr"`
+}
+
+// ExtensionGroup ...
+type ExtensionGroup struct {
+	XMLName xml.Name `xml:"extensionGroup"`
+	Note    string   `xml:"note"`
+	Any     []struct {
+		XMLName  xml.Name
+		Attrs    []xml.Attr `xml:",any,attr"`
+		InnerXML string     `xml:",innerxml"`
+	} `xml:",any"`
+}
+
+// Envelope ...
+type Envelope struct {
+	IdAttr   string     `xml:"id,attr"`
+	AnyAttrs []xml.Attr `xml:",any,attr"`
+	Header   string     `xml:"header"`
+	Any      []struct {
+		XMLName  xml.Name
+		Attrs    []xml.Attr `xml:",any,attr"`
+		InnerXML string     `xml:",innerxml"`
+	} `xml:",any"`
+}
@@ -12,14 +12,14 @@ type MyType1 string
 // MyType2 is appinfo-myType2-appinfo
 type MyType2 struct {
 	XMLName    xml.Name `xml:"myType2"`
-	LengthAttr *int     `xml:"length,attr"`
+	LengthAttr *int     `xml:"length,attr,omitempty"`
 	Value      string   `xml:",chardata"`
 }
 
 // MyType3 ...
 type MyType3 struct {
 	XMLName    xml.Name `xml:"myType3"`
-	LengthAttr *int     `xml:"length,attr"`
+	LengthAttr *int     `xml:"length,attr,omitempty"`
 	Value      string   `xml:",chardata"`
 }
 
@@ -29,7 +29,7 @@ type MyType4 struct {
 	Title     string   `xml:"title"`
 	Blob      string   `xml:"blob"`
 	Timestamp string   `xml:"timestamp"`
-	Metadata  *string  `xml:"metadata"`
+	Metadata  *string  `xml:"metadata,omitempty"`
 }
 
 // MyType5 ...
@@ -37,8 +37,8 @@ type MyType5 string
 
 // MyType6 ...
 type MyType6 struct {
-	CodeAttr       *string `xml:"code,attr"`
-	IdentifierAttr *int    `xml:"identifier,attr"`
+	CodeAttr       *string `xml:"code,attr,omitempty"`
+	IdentifierAttr *int    `xml:"identifier,attr,omitempty"`
 }
 
 // MyType7 ...
@@ -64,17 +64,18 @@ type MyType10 struct {
 
 // MyType11 ...
 type MyType11 struct {
-	Option1 *int      `xml:"option1"`
-	Option2 *string   `xml:"option2"`
-	Option3 *MyType10 `xml:"option3"`
+	Option1 *int      `xml:"option1,omitempty"`
+	Option2 *string   `xml:"option2,omitempty"`
+	Option3 *MyType10 `xml:"option3,omitempty"`
 }
 
 // TopLevel ...
 type TopLevel struct {
-	CostAttr        *float64   `xml:"cost,attr"`
+	XMLName         xml.Name   `xml:"http://example.org/ TopLevel"`
+	CostAttr        *float64   `xml:"cost,attr,omitempty"`
 	LastUpdatedAttr string     `xml:"LastUpdated,attr"`
-	Nested          *MyType7   `xml:"nested"`
-	MyType1         []string   `xml:"myType1"`
-	MyType2         []*MyType2 `xml:"myType2"`
+	Nested          *MyType7   `xml:"nested,omitempty"`
+	MyType1         []string   `xml:"myType1,omitempty"`
+	MyType2         []*MyType2 `xml:"myType2,omitempty"`
 	*MyType6
 }
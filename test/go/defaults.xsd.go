@@ -0,0 +1,110 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// SeatClass ...
+type SeatClass string
+
+// Enumeration values of SeatClass.
+const (
+	SeatClassEconomy  SeatClass = "economy"
+	SeatClassBusiness SeatClass = "business"
+)
+
+// Values returns the enumeration values of SeatClass.
+func (v SeatClass) Values() []SeatClass {
+	return []SeatClass{
+		SeatClassEconomy,
+		SeatClassBusiness,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of SeatClass.
+func (v SeatClass) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of SeatClass.
+func (v *SeatClass) UnmarshalText(text []byte) error {
+	value := SeatClass(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for SeatClass", text)
+	}
+	*v = value
+	return nil
+}
+
+// AuditAttrs ...
+type AuditAttrs struct {
+	XMLName      xml.Name `xml:"auditAttrs"`
+	SourceAttr   *string  `xml:"source,attr,omitempty"`
+	RevisionAttr *int     `xml:"revision,attr,omitempty"`
+}
+
+// NewAuditAttrs returns a new AuditAttrs with the default values of the XML schema.
+func NewAuditAttrs() *AuditAttrs {
+	defaultSourceAttr := "web"
+	defaultRevisionAttr := 1
+	return &AuditAttrs{
+		SourceAttr:   &defaultSourceAttr,
+		RevisionAttr: &defaultRevisionAttr,
+	}
+}
+
+// Seating ...
+type Seating struct {
+	XMLName xml.Name  `xml:"seating"`
+	Seat    SeatClass `xml:"seat"`
+}
+
+// NewSeating returns a new Seating with the default values of the XML schema.
+func NewSeating() *Seating {
+	return &Seating{
+		Seat: "economy",
+	}
+}
+
+// Ticket ...
+type Ticket struct {
+	TnsAuditAttrs *AuditAttrs
+	LangAttr      *string    `xml:"lang,attr,omitempty"`
+	VersionAttr   *float64   `xml:"version,attr,omitempty"`
+	ClassAttr     *SeatClass `xml:"class,attr,omitempty"`
+	TnsSeating    *Seating
+	Holder        string   `xml:"holder"`
+	Quantity      int      `xml:"quantity"`
+	Price         *float64 `xml:"price,omitempty"`
+	Refundable    *bool    `xml:"refundable,omitempty"`
+	Currency      string   `xml:"currency"`
+	Stamp         string   `xml:"stamp"`
+}
+
+// NewTicket returns a new Ticket with the default values of the XML schema.
+func NewTicket() *Ticket {
+	defaultLangAttr := "en"
+	defaultVersionAttr := float64(2.0)
+	defaultClassAttr := SeatClass("business")
+	defaultPrice := float64(9.5)
+	defaultRefundable := false
+	return &Ticket{
+		LangAttr:    &defaultLangAttr,
+		VersionAttr: &defaultVersionAttr,
+		ClassAttr:   &defaultClassAttr,
+		Quantity:    1,
+		Price:       &defaultPrice,
+		Refundable:  &defaultRefundable,
+		Currency:    "EUR",
+		Stamp:       "12:00:00",
+	}
+}
@@ -0,0 +1,62 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// Amount ...
+type Amount float64
+
+// PositiveAmount ...
+type PositiveAmount float64
+
+// Price ...
+type Price struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	NoteAttr     *string `xml:"note,attr,omitempty"`
+	Value        float64 `xml:",chardata"`
+}
+
+// LocalPrice ...
+type LocalPrice struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	Value        float64 `xml:",chardata"`
+}
+
+// TaxedPrice ...
+type TaxedPrice struct {
+	TaxAttr *float64 `xml:"tax,attr,omitempty"`
+	*Price
+}
+
+// NetPrice ...
+type NetPrice struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	NoteAttr     *string `xml:"note,attr,omitempty"`
+	TaxAttr      float64 `xml:"tax,attr"`
+	Value        float64 `xml:",chardata"`
+}
+
+// Fee ...
+type Fee struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	Value        float64 `xml:",chardata"`
+}
+
+// SmallFee ...
+type SmallFee struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	Value        float64 `xml:",chardata"`
+}
+
+// Item ...
+type Item struct {
+	SkuAttr string  `xml:"sku,attr"`
+	Name    string  `xml:"name"`
+	Price   *Price  `xml:"price,omitempty"`
+	Comment *string `xml:"comment,omitempty"`
+}
+
+// Gift ...
+type Gift struct {
+	SkuAttr string `xml:"sku,attr"`
+	Name    string `xml:"name"`
+}
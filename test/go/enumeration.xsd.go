@@ -0,0 +1,144 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// ShirtColor is The available colors of a shirt.
+type ShirtColor string
+
+// Enumeration values of ShirtColor.
+const (
+	ShirtColorRed        ShirtColor = "red"
+	ShirtColorDarkBlue   ShirtColor = "dark-blue"
+	ShirtColorLightGreen ShirtColor = "light green"
+)
+
+// Values returns the enumeration values of ShirtColor.
+func (v ShirtColor) Values() []ShirtColor {
+	return []ShirtColor{
+		ShirtColorRed,
+		ShirtColorDarkBlue,
+		ShirtColorLightGreen,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of ShirtColor.
+func (v ShirtColor) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of ShirtColor.
+func (v *ShirtColor) UnmarshalText(text []byte) error {
+	value := ShirtColor(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for ShirtColor", text)
+	}
+	*v = value
+	return nil
+}
+
+// ShirtSize ...
+type ShirtSize int
+
+// Enumeration values of ShirtSize.
+const (
+	ShirtSize1 ShirtSize = 1
+	ShirtSize2 ShirtSize = 2
+	ShirtSize3 ShirtSize = 3
+)
+
+// Values returns the enumeration values of ShirtSize.
+func (v ShirtSize) Values() []ShirtSize {
+	return []ShirtSize{
+		ShirtSize1,
+		ShirtSize2,
+		ShirtSize3,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of ShirtSize.
+func (v ShirtSize) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of ShirtSize.
+func (v *ShirtSize) UnmarshalText(text []byte) error {
+	var value ShirtSize
+	if _, err := fmt.Sscan(string(text), &value); err != nil {
+		return fmt.Errorf("invalid value %q for ShirtSize: %w", text, err)
+	}
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for ShirtSize", text)
+	}
+	*v = value
+	return nil
+}
+
+// Shirt ...
+type Shirt struct {
+	SizeAttr         ShirtSize     `xml:"size,attr"`
+	FitAttr          *ShirtColor   `xml:"fit,attr,omitempty"`
+	Color            ShirtColor    `xml:"color"`
+	Sleeve           *SleeveLength `xml:"sleeve,omitempty"`
+	AlternativeColor []ShirtColor  `xml:"alternativeColor,omitempty"`
+}
+
+// SleeveLength ...
+type SleeveLength string
+
+// Enumeration values of SleeveLength.
+const (
+	SleeveLengthShort SleeveLength = "short"
+	SleeveLengthLong  SleeveLength = "long"
+)
+
+// Values returns the enumeration values of SleeveLength.
+func (v SleeveLength) Values() []SleeveLength {
+	return []SleeveLength{
+		SleeveLengthShort,
+		SleeveLengthLong,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of SleeveLength.
+func (v SleeveLength) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of SleeveLength.
+func (v *SleeveLength) UnmarshalText(text []byte) error {
+	value := SleeveLength(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for SleeveLength", text)
+	}
+	*v = value
+	return nil
+}
+
+// Wardrobe ...
+type Wardrobe struct {
+	XMLName xml.Name `xml:"http://example.org/enumeration Wardrobe"`
+	Shirt   []*Shirt `xml:"shirt"`
+}
@@ -0,0 +1,183 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// ShapeType ...
+type ShapeType struct {
+	IdAttr *string `xml:"id,attr,omitempty"`
+}
+
+// CircleType ...
+type CircleType struct {
+	Radius float64 `xml:"radius"`
+}
+
+// SquareType ...
+type SquareType struct {
+	Side float64 `xml:"side"`
+}
+
+// Shape ...
+type Shape *ShapeType
+
+// Circle ...
+type Circle *CircleType
+
+// Square ...
+type Square *SquareType
+
+// Cube ...
+type Cube *SquareType
+
+// ShapeSubstitution holds an element in the substitution group of shape.
+type ShapeSubstitution struct {
+	XMLName xml.Name
+	Value   interface{}
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface and decodes the
+// element with the type of the member in the substitution group.
+func (v *ShapeSubstitution) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "circle":
+		v.Value = new(CircleType)
+	case "square":
+		v.Value = new(SquareType)
+	case "cube":
+		v.Value = new(SquareType)
+	default:
+		return fmt.Errorf("unexpected element %s in substitution group of shape", start.Name.Local)
+	}
+	v.XMLName = start.Name
+	return d.DecodeElement(v.Value, &start)
+}
+
+// MarshalXML implements the xml.Marshaler interface and encodes the element
+// with its name.
+func (v *ShapeSubstitution) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = v.XMLName
+	return e.EncodeElement(v.Value, start)
+}
+
+// Drawing ...
+type Drawing struct {
+	Title string              `xml:"title"`
+	Shape []ShapeSubstitution `xml:",any"`
+}
+
+// Color ...
+type Color string
+
+// Red ...
+type Red string
+
+// Blue ...
+type Blue string
+
+// ColorSubstitution holds an element in the substitution group of color.
+type ColorSubstitution struct {
+	XMLName xml.Name
+	Value   interface{}
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface and decodes the
+// element with the type of the member in the substitution group.
+func (v *ColorSubstitution) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "red":
+		v.Value = new(string)
+	case "blue":
+		v.Value = new(string)
+	default:
+		return fmt.Errorf("unexpected element %s in substitution group of color", start.Name.Local)
+	}
+	v.XMLName = start.Name
+	return d.DecodeElement(v.Value, &start)
+}
+
+// MarshalXML implements the xml.Marshaler interface and encodes the element
+// with its name.
+func (v *ColorSubstitution) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = v.XMLName
+	return e.EncodeElement(v.Value, start)
+}
+
+// PaletteElements holds the elements of Palette in the
+// substitution groups and the wildcard, which are dispatched to the fields
+// by their names.
+type PaletteElements struct {
+	Shape *ShapeSubstitution
+	Color *ColorSubstitution
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface and decodes the
+// element into the field of its substitution group or the wildcard.
+func (v *PaletteElements) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "circle", "square", "cube":
+		return d.DecodeElement(&v.Shape, &start)
+	case "red", "blue":
+		return d.DecodeElement(&v.Color, &start)
+	}
+	return fmt.Errorf("unexpected element %s in Palette", start.Name.Local)
+}
+
+// MarshalXML implements the xml.Marshaler interface and encodes the elements
+// of the fields in order.
+func (v *PaletteElements) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, field := range []interface{}{v.Shape, v.Color} {
+		if err := e.Encode(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Palette ...
+type Palette struct {
+	Elements *PaletteElements `xml:",any,omitempty"`
+}
+
+// BoardElements holds the elements of Board in the
+// substitution groups and the wildcard, which are dispatched to the fields
+// by their names.
+type BoardElements struct {
+	Shape []ShapeSubstitution
+	Any   []struct {
+		XMLName  xml.Name
+		Attrs    []xml.Attr `xml:",any,attr"`
+		InnerXML string     `xml:",innerxml"`
+	}
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface and decodes the
+// element into the field of its substitution group or the wildcard.
+func (v *BoardElements) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "circle", "square", "cube":
+		return d.DecodeElement(&v.Shape, &start)
+	}
+	return d.DecodeElement(&v.Any, &start)
+}
+
+// MarshalXML implements the xml.Marshaler interface and encodes the elements
+// of the fields in order.
+func (v *BoardElements) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, field := range []interface{}{v.Shape, v.Any} {
+		if err := e.Encode(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Board ...
+type Board struct {
+	Name     string         `xml:"name"`
+	Elements *BoardElements `xml:",any,omitempty"`
+}
@@ -0,0 +1,47 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ExtensionAttributes ...
+public class ExtensionAttributes {
+	@XmlAttribute(name = "version")
+	protected String VersionAttr;
+	@XmlAnyAttribute
+	protected Map<QName, String> AnyAttrs;
+}
+
+// ExtensionGroup ...
+public class ExtensionGroup {
+	@XmlElement(required = true, name = "note")
+	protected String Note;
+	@XmlAnyElement
+	protected List<Object> Any;
+}
+
+// Envelope ...
+public class Envelope {
+	@XmlAttribute(required = true, name = "id")
+	protected String IdAttr;
+	@XmlAnyAttribute
+	protected Map<QName, String> AnyAttrs;
+	@XmlElement(required = true, name = "header")
+	protected String Header;
+	@XmlAnyElement(lax = true)
+	protected List<Object> Any;
+}
@@ -4,13 +4,19 @@ package schema;
 
 import java.util.ArrayList;
 import java.util.List;
+import java.util.Map;
 import javax.xml.bind.annotation.XmlAccessType;
 import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
 import javax.xml.bind.annotation.XmlAttribute;
 import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
 import javax.xml.bind.annotation.XmlSchemaType;
 import javax.xml.bind.annotation.XmlType;
 import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
 
 // MyType1 ...
 @XmlAccessorType(XmlAccessType.FIELD)
@@ -99,6 +105,7 @@ public class MyType11 {
 }
 
 // TopLevel ...
+@XmlRootElement(name = "TopLevel", namespace = "http://example.org/")
 public class TopLevel extends MyType6  {
 	@XmlAttribute(name = "cost")
 	protected Float CostAttr;
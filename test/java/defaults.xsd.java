@@ -0,0 +1,65 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// SeatClass ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "seatClass")
+public class SeatClass {
+	protected String SeatClass;
+}
+
+// AuditAttrs ...
+public class AuditAttrs {
+	@XmlAttribute(name = "source")
+	protected String SourceAttr = "web";
+	@XmlAttribute(name = "revision")
+	protected Integer RevisionAttr = 1;
+}
+
+// Seating ...
+public class Seating {
+	@XmlElement(required = true, name = "seat")
+	protected String Seat = "economy";
+}
+
+// Ticket ...
+public class Ticket {
+	@XmlElement(required = true)
+	protected AuditAttrs TnsAuditAttrs;
+	@XmlAttribute(name = "lang")
+	protected String LangAttr = "en";
+	@XmlAttribute(name = "version")
+	protected Float VersionAttr = 2f;
+	@XmlAttribute(name = "class")
+	protected String ClassAttr = "business";
+	protected Seating TnsSeating;
+	@XmlElement(required = true, name = "holder")
+	protected String Holder;
+	@XmlElement(required = true, name = "quantity")
+	protected Integer Quantity = 1;
+	@XmlElement(name = "price")
+	protected Float Price = 9.5f;
+	@XmlElement(name = "refundable")
+	protected Boolean Refundable = false;
+	@XmlElement(required = true, name = "currency")
+	protected String Currency = "EUR";
+	@XmlElement(required = true, name = "stamp")
+	protected String Stamp = "12:00:00";
+}
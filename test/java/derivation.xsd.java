@@ -0,0 +1,105 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// Amount ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Amount")
+public class Amount {
+	protected Float Amount;
+}
+
+// PositiveAmount ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "PositiveAmount")
+public class PositiveAmount {
+	protected Float PositiveAmount;
+}
+
+// Price ...
+public class Price {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlAttribute(name = "note")
+	protected String NoteAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// LocalPrice ...
+public class LocalPrice {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// TaxedPrice ...
+public class TaxedPrice extends Price  {
+	@XmlAttribute(name = "tax")
+	protected Float TaxAttr;
+}
+
+// NetPrice ...
+public class NetPrice {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlAttribute(name = "note")
+	protected String NoteAttr;
+	@XmlAttribute(required = true, name = "tax")
+	protected Float TaxAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Fee ...
+public class Fee {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// SmallFee ...
+public class SmallFee {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Item ...
+public class Item {
+	@XmlAttribute(required = true, name = "sku")
+	protected String SkuAttr;
+	@XmlElement(required = true, name = "name")
+	protected String Name;
+	@XmlElement(name = "price")
+	protected Price Price;
+	@XmlElement(name = "comment")
+	protected String Comment;
+}
+
+// Gift ...
+public class Gift {
+	@XmlAttribute(required = true, name = "sku")
+	protected String SkuAttr;
+	@XmlElement(required = true, name = "name")
+	protected String Name;
+}
@@ -0,0 +1,61 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ShirtColor is The available colors of a shirt.
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "shirtColor")
+public class ShirtColor {
+	protected String ShirtColor;
+}
+
+// ShirtSize ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "shirtSize")
+public class ShirtSize {
+	protected Integer ShirtSize;
+}
+
+// Shirt ...
+public class Shirt {
+	@XmlAttribute(required = true, name = "size")
+	protected Integer SizeAttr;
+	@XmlAttribute(name = "fit")
+	protected String FitAttr;
+	@XmlElement(required = true, name = "color")
+	protected String Color;
+	@XmlElement(name = "sleeve")
+	protected String Sleeve;
+	@XmlElement(name = "alternativeColor")
+	protected List<String> AlternativeColor;
+}
+
+// SleeveLength ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "sleeveLength")
+public class SleeveLength {
+	protected String SleeveLength;
+}
+
+// Wardrobe ...
+@XmlRootElement(name = "Wardrobe", namespace = "http://example.org/enumeration")
+public class Wardrobe {
+	@XmlElement(required = true, name = "shirt")
+	protected List<Shirt> Shirt;
+}
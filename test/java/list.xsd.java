@@ -0,0 +1,83 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Sizes")
+public class Sizes {
+	protected List<Integer> Sizes;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Tags")
+public class Tags {
+	protected List<String> Tags;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Weights")
+public class Weights {
+	protected List<Float> Weights;
+}
+
+// SizeName ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "SizeName")
+public class SizeName {
+	protected String SizeName;
+}
+
+// ClothingSize ...
+public class ClothingSize {
+	@XmlElement(required = true)
+	protected Integer Int;
+	@XmlElement(required = true)
+	protected String SizeName;
+}
+
+// Threshold ...
+public class Threshold {
+	@XmlElement(required = true)
+	protected Boolean Boolean;
+	@XmlElement(required = true)
+	protected Float Decimal;
+	@XmlElement(required = true)
+	protected String String;
+}
+
+// Garment ...
+public class Garment {
+	@XmlAttribute(name = "tags")
+	protected Tags TagsAttr;
+	@XmlAttribute(name = "threshold")
+	protected Threshold ThresholdAttr;
+	@XmlElement(required = true, name = "sizes")
+	protected Sizes Sizes;
+	@XmlElement(required = true, name = "size")
+	protected ClothingSize Size;
+	@XmlElement(name = "weights")
+	protected Weights Weights;
+}
+
+// Catalog ...
+@XmlRootElement(name = "Catalog")
+public class Catalog {
+	@XmlElement(required = true, name = "garment")
+	protected List<Garment> Garment;
+}
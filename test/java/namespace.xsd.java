@@ -0,0 +1,54 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "warehouse", namespace = "http://example.org/inventory")
+public class Warehouse {
+	protected String Warehouse;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "audited")
+public class Audited {
+	protected Boolean Audited;
+}
+
+// StockItem ...
+public class StockItem {
+	@XmlAttribute(required = true, name = "id")
+	protected String IdAttr;
+	@XmlAttribute(name = "status", namespace = "http://example.org/inventory")
+	protected String StatusAttr;
+	@XmlAttribute(name = "audited", namespace = "http://example.org/inventory")
+	protected Boolean InvAuditedAttr;
+	@XmlElement(required = true, name = "name", namespace = "http://example.org/inventory")
+	protected String Name;
+	@XmlElement(name = "comment")
+	protected String Comment;
+	@XmlElement(required = true, name = "warehouse", namespace = "http://example.org/inventory")
+	protected String InvWarehouse;
+}
+
+// Inventory ...
+@XmlRootElement(name = "Inventory", namespace = "http://example.org/inventory")
+public class Inventory {
+	@XmlElement(required = true, name = "item", namespace = "http://example.org/inventory")
+	protected List<StockItem> Item;
+}
@@ -0,0 +1,120 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ShapeType ...
+public class ShapeType {
+	@XmlAttribute(name = "id")
+	protected String IdAttr;
+}
+
+// CircleType ...
+public class CircleType {
+	@XmlElement(required = true, name = "radius")
+	protected Float Radius;
+}
+
+// SquareType ...
+public class SquareType {
+	@XmlElement(required = true, name = "side")
+	protected Float Side;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "shape")
+public class Shape {
+	protected ShapeType Shape;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "circle")
+public class Circle {
+	protected CircleType Circle;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "square")
+public class Square {
+	protected SquareType Square;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "cube")
+public class Cube {
+	protected SquareType Cube;
+}
+
+// Drawing ...
+public class Drawing {
+	@XmlElement(required = true, name = "title")
+	protected String Title;
+	@XmlElements({
+		@XmlElement(name = "circle", type = CircleType.class),
+		@XmlElement(name = "square", type = SquareType.class),
+		@XmlElement(name = "cube", type = SquareType.class)
+	})
+	protected List<Object> Shape;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "color")
+public class Color {
+	protected String Color;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "red")
+public class Red {
+	protected String Red;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "blue")
+public class Blue {
+	protected String Blue;
+}
+
+// Palette ...
+public class Palette {
+	@XmlElements({
+		@XmlElement(name = "circle", type = CircleType.class),
+		@XmlElement(name = "square", type = SquareType.class),
+		@XmlElement(name = "cube", type = SquareType.class)
+	})
+	protected Object Shape;
+	@XmlElements({
+		@XmlElement(name = "red", type = String.class),
+		@XmlElement(name = "blue", type = String.class)
+	})
+	protected Object Color;
+}
+
+// Board ...
+public class Board {
+	@XmlElement(required = true, name = "name")
+	protected String Name;
+	@XmlElements({
+		@XmlElement(name = "circle", type = CircleType.class),
+		@XmlElement(name = "square", type = SquareType.class),
+		@XmlElement(name = "cube", type = SquareType.class)
+	})
+	protected List<Object> Shape;
+	@XmlAnyElement(lax = true)
+	protected List<Object> Any;
+}
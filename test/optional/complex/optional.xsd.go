@@ -0,0 +1,204 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/xuri/xgen/xsd"
+)
+
+// Code ...
+type Code string
+
+// Validate checks the value of Code against the facets of the XML schema.
+func (v Code) Validate() error {
+	if n := len([]rune(string(v))); n < 2 {
+		return fmt.Errorf("Code: length %d is less than 2", n)
+	}
+	if n := len([]rune(string(v))); n > 4 {
+		return fmt.Errorf("Code: length %d is greater than 4", n)
+	}
+	return nil
+}
+
+// Level ...
+type Level string
+
+// Enumeration values of Level.
+const (
+	LevelLow  Level = "low"
+	LevelHigh Level = "high"
+)
+
+// Values returns the enumeration values of Level.
+func (v Level) Values() []Level {
+	return []Level{
+		LevelLow,
+		LevelHigh,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of Level.
+func (v Level) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of Level.
+func (v *Level) UnmarshalText(text []byte) error {
+	value := Level(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for Level", text)
+	}
+	*v = value
+	return nil
+}
+
+// Validate checks the value of Level against the facets of the XML schema.
+func (v Level) Validate() error {
+	if !v.IsValid() {
+		return fmt.Errorf("Level: invalid value %v", v)
+	}
+	return nil
+}
+
+// Address ...
+type Address struct {
+	Street string `xml:"street"`
+}
+
+// Validate checks the fields of Address against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Address) Validate() error {
+	if v == nil {
+		return nil
+	}
+	return nil
+}
+
+// Audit ...
+type Audit struct {
+	XMLName      xml.Name `xml:"audit"`
+	AuthorAttr   Code     `xml:"author,attr,omitempty"`
+	RevisionAttr int      `xml:"revision,attr,omitempty"`
+}
+
+// NewAudit returns a new Audit with the default values of the XML schema.
+func NewAudit() *Audit {
+	return &Audit{
+		RevisionAttr: 1,
+	}
+}
+
+// Validate checks the fields of Audit against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Audit) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if !xsd.IsZero(v.AuthorAttr) {
+		if err := v.AuthorAttr.Validate(); err != nil {
+			return fmt.Errorf("Audit.AuthorAttr: %w", err)
+		}
+	}
+	return nil
+}
+
+// Contact ...
+type Contact struct {
+	XMLName xml.Name `xml:"contact"`
+	Email   string   `xml:"email,omitempty"`
+	Phone   string   `xml:"phone"`
+}
+
+// Validate checks the fields of Contact against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Contact) Validate() error {
+	if v == nil {
+		return nil
+	}
+	return nil
+}
+
+// Profile ...
+type Profile struct {
+	Audit       *Audit
+	IdAttr      string   `xml:"id,attr"`
+	ActiveAttr  bool     `xml:"active,attr,omitempty"`
+	VersionAttr float64  `xml:"version,attr,omitempty"`
+	Name        string   `xml:"name"`
+	Nickname    string   `xml:"nickname,omitempty"`
+	Code        Code     `xml:"code,omitempty"`
+	Level       Level    `xml:"level,omitempty"`
+	Age         int      `xml:"age,omitempty"`
+	Address     *Address `xml:"address,omitempty"`
+	Tag         []string `xml:"tag,omitempty"`
+}
+
+// NewProfile returns a new Profile with the default values of the XML schema.
+func NewProfile() *Profile {
+	return &Profile{
+		VersionAttr: 1.5,
+		Level:       "low",
+	}
+}
+
+// Validate checks the fields of Profile against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Profile) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if err := v.Audit.Validate(); err != nil {
+		return fmt.Errorf("Profile.Audit: %w", err)
+	}
+	if !xsd.IsZero(v.Code) {
+		if err := v.Code.Validate(); err != nil {
+			return fmt.Errorf("Profile.Code: %w", err)
+		}
+	}
+	if !xsd.IsZero(v.Level) {
+		if err := v.Level.Validate(); err != nil {
+			return fmt.Errorf("Profile.Level: %w", err)
+		}
+	}
+	if err := v.Address.Validate(); err != nil {
+		return fmt.Errorf("Profile.Address: %w", err)
+	}
+	if v.VersionAttr != 0 && v.VersionAttr != 1.5 {
+		return fmt.Errorf("Profile.VersionAttr: value %v is not the fixed value %v", v.VersionAttr, 1.5)
+	}
+	return nil
+}
+
+// Profiles ...
+type Profiles struct {
+	Contact *Contact
+	Profile []*Profile `xml:"profile"`
+}
+
+// Validate checks the fields of Profiles against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Profiles) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if err := v.Contact.Validate(); err != nil {
+		return fmt.Errorf("Profiles.Contact: %w", err)
+	}
+	if len(v.Profile) == 0 {
+		return fmt.Errorf("Profiles: missing required element %q", "profile")
+	}
+	for _, item := range v.Profile {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("Profiles.Profile: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package order
+
+import (
+	"encoding/xml"
+	"github.com/xuri/xgen/test/packages/go/billing"
+	"github.com/xuri/xgen/test/packages/go/shipping"
+)
+
+// Address ...
+type Address struct {
+	Email string `xml:"http://example.org/order email"`
+}
+
+// Destination ...
+type Destination struct {
+	Instructions *string `xml:"http://example.org/order instructions,omitempty"`
+	*shipping.Address
+}
+
+// Order ...
+type Order struct {
+	XMLName      xml.Name            `xml:"http://example.org/order order"`
+	CurrencyAttr billing.Currency    `xml:"currency,attr"`
+	Contact      *Address            `xml:"http://example.org/order contact"`
+	Billing      *billing.Address    `xml:"http://example.org/order billing"`
+	Shipping     []*shipping.Address `xml:"http://example.org/order shipping"`
+	Destination  *Destination        `xml:"http://example.org/order destination,omitempty"`
+}
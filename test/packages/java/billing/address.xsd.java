@@ -0,0 +1,36 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package billing;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// Currency ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "Currency")
+public class Currency {
+	protected String Currency;
+}
+
+// Address ...
+public class Address {
+	@XmlAttribute(name = "currency")
+	protected String CurrencyAttr;
+	@XmlElement(required = true, name = "name", namespace = "http://example.org/billing")
+	protected String Name;
+	@XmlElement(required = true, name = "iban", namespace = "http://example.org/billing")
+	protected String Iban;
+}
@@ -0,0 +1,46 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package order;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// Address ...
+public class Address {
+	@XmlElement(required = true, name = "email", namespace = "http://example.org/order")
+	protected String Email;
+}
+
+// Destination ...
+public class Destination extends shipping.Address  {
+	@XmlElement(name = "instructions", namespace = "http://example.org/order")
+	protected String Instructions;
+}
+
+// Order ...
+@XmlRootElement(name = "order", namespace = "http://example.org/order")
+public class Order {
+	@XmlAttribute(required = true, name = "currency")
+	protected String CurrencyAttr;
+	@XmlElement(required = true, name = "contact", namespace = "http://example.org/order")
+	protected Address Contact;
+	@XmlElement(required = true, name = "billing", namespace = "http://example.org/order")
+	protected billing.Address Billing;
+	@XmlElement(required = true, name = "shipping", namespace = "http://example.org/order")
+	protected List<shipping.Address> Shipping;
+	@XmlElement(name = "destination", namespace = "http://example.org/order")
+	protected Destination Destination;
+}
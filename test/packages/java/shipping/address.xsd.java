@@ -0,0 +1,29 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package shipping;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlRootElement;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// Address ...
+public class Address {
+	@XmlElement(required = true, name = "street", namespace = "http://example.org/shipping")
+	protected String Street;
+	@XmlElement(required = true, name = "city", namespace = "http://example.org/shipping")
+	protected String City;
+	@XmlElement(required = true, name = "country", namespace = "http://example.org/shipping")
+	protected String Country;
+}
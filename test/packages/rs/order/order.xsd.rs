@@ -0,0 +1,42 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+use crate::billing;
+use crate::shipping;
+
+
+// Address ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Address {
+	#[serde(rename = "email", alias = "o:email")]
+	pub email: String,
+}
+
+
+// Destination ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Destination {
+	#[serde(rename = "instructions", alias = "o:instructions")]
+	pub instructions: Option<String>,
+	#[serde(flatten)]
+	pub address: shipping::Address,
+}
+
+
+// Order ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Order {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "contact", alias = "o:contact")]
+	pub contact: Address,
+	#[serde(rename = "billing", alias = "o:billing")]
+	pub billing: billing::Address,
+	#[serde(rename = "shipping", alias = "o:shipping")]
+	pub shipping: Vec<shipping::Address>,
+	#[serde(rename = "destination", alias = "o:destination")]
+	pub destination: Option<Destination>,
+}
@@ -0,0 +1,23 @@
+// Code generated by xgen. DO NOT EDIT.
+
+import * as billing from "../billing/address.xsd";
+import * as shipping from "../shipping/address.xsd";
+
+// Address ...
+export class Address {
+	Email: string;
+}
+
+// Destination ...
+export class Destination extends shipping.Address  {
+	Instructions?: string;
+}
+
+// Order ...
+export class Order {
+	CurrencyAttr: string;
+	Contact: Address;
+	Billing: billing.Address;
+	Shipping: Array<shipping.Address>;
+	Destination?: Destination;
+}
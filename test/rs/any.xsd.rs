@@ -0,0 +1,38 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// ExtensionAttributes ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExtensionAttributes {
+	#[serde(rename = "version")]
+	pub version: Option<String>,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
+
+
+// ExtensionGroup ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExtensionGroup {
+	#[serde(rename = "note")]
+	pub note: String,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
+
+
+// Envelope ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Envelope {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+	#[serde(rename = "header")]
+	pub header: String,
+}
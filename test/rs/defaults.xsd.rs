@@ -0,0 +1,104 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// SeatClass ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SeatClass {
+	#[serde(rename = "seatClass")]
+	pub seat_class: String,
+}
+
+
+// AuditAttrs ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct AuditAttrs {
+	#[serde(rename = "source", default = "default_audit_attrs_source")]
+	pub source: Option<String>,
+	#[serde(rename = "revision", default = "default_audit_attrs_revision")]
+	pub revision: Option<i32>,
+}
+
+fn default_audit_attrs_source() -> Option<String> {
+	Some("web".to_string())
+}
+
+fn default_audit_attrs_revision() -> Option<i32> {
+	Some(1)
+}
+
+
+// Seating ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Seating {
+	#[serde(rename = "seat", default = "default_seating_seat")]
+	pub seat: String,
+}
+
+fn default_seating_seat() -> String {
+	"economy".to_string()
+}
+
+
+// Ticket ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Ticket {
+	#[serde(rename = "tns:auditAttrs")]
+	pub tns_audit_attrs: Vec<AuditAttrs>,
+	#[serde(rename = "lang", default = "default_ticket_lang")]
+	pub lang: Option<String>,
+	#[serde(rename = "version", default = "default_ticket_version")]
+	pub version: Option<f64>,
+	#[serde(rename = "class", default = "default_ticket_class")]
+	pub class: Option<String>,
+	#[serde(rename = "tns:seating")]
+	pub tns_seating: Seating,
+	#[serde(rename = "holder")]
+	pub holder: String,
+	#[serde(rename = "quantity", default = "default_ticket_quantity")]
+	pub quantity: i32,
+	#[serde(rename = "price", default = "default_ticket_price")]
+	pub price: Option<f64>,
+	#[serde(rename = "refundable", default = "default_ticket_refundable")]
+	pub refundable: Option<bool>,
+	#[serde(rename = "currency", default = "default_ticket_currency")]
+	pub currency: String,
+	#[serde(rename = "stamp", default = "default_ticket_stamp")]
+	pub stamp: String,
+}
+
+fn default_ticket_lang() -> Option<String> {
+	Some("en".to_string())
+}
+
+fn default_ticket_version() -> Option<f64> {
+	Some(2.0)
+}
+
+fn default_ticket_class() -> Option<String> {
+	Some("business".to_string())
+}
+
+fn default_ticket_quantity() -> i32 {
+	1
+}
+
+fn default_ticket_price() -> Option<f64> {
+	Some(9.5)
+}
+
+fn default_ticket_refundable() -> Option<bool> {
+	Some(false)
+}
+
+fn default_ticket_currency() -> String {
+	"EUR".to_string()
+}
+
+fn default_ticket_stamp() -> String {
+	"12:00:00".to_string()
+}
@@ -0,0 +1,112 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// Amount ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Amount {
+	#[serde(rename = "Amount")]
+	pub amount: f64,
+}
+
+
+// PositiveAmount ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PositiveAmount {
+	#[serde(rename = "PositiveAmount")]
+	pub positive_amount: f64,
+}
+
+
+// Price ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Price {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "note")]
+	pub note: Option<String>,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// LocalPrice ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct LocalPrice {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// TaxedPrice ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct TaxedPrice {
+	#[serde(rename = "tax")]
+	pub tax: Option<f64>,
+	#[serde(flatten)]
+	pub price: Price,
+}
+
+
+// NetPrice ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct NetPrice {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "note")]
+	pub note: Option<String>,
+	#[serde(rename = "tax")]
+	pub tax: f64,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Fee ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Fee {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// SmallFee ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SmallFee {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Item ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Item {
+	#[serde(rename = "sku")]
+	pub sku: String,
+	#[serde(rename = "name")]
+	pub name: String,
+	#[serde(rename = "price")]
+	pub price: Option<Price>,
+	#[serde(rename = "comment")]
+	pub comment: Option<String>,
+}
+
+
+// Gift ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Gift {
+	#[serde(rename = "sku")]
+	pub sku: String,
+	#[serde(rename = "name")]
+	pub name: String,
+}
@@ -0,0 +1,54 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// ShirtColor is The available colors of a shirt.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShirtColor {
+	#[serde(rename = "shirtColor")]
+	pub shirt_color: String,
+}
+
+
+// ShirtSize ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShirtSize {
+	#[serde(rename = "shirtSize")]
+	pub shirt_size: i32,
+}
+
+
+// Shirt ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Shirt {
+	#[serde(rename = "size")]
+	pub size: i32,
+	#[serde(rename = "fit")]
+	pub fit: Option<String>,
+	#[serde(rename = "color")]
+	pub color: String,
+	#[serde(rename = "sleeve")]
+	pub sleeve: Option<String>,
+	#[serde(rename = "alternativeColor")]
+	pub alternative_color: Vec<String>,
+}
+
+
+// SleeveLength ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SleeveLength {
+	#[serde(rename = "sleeveLength")]
+	pub sleeve_length: String,
+}
+
+
+// Wardrobe ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Wardrobe {
+	#[serde(rename = "shirt")]
+	pub shirt: Vec<Shirt>,
+}
@@ -0,0 +1,48 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// warehouse ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct warehouse {
+	#[serde(rename = "warehouse")]
+	pub warehouse: String,
+}
+
+
+// audited ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct audited {
+	#[serde(rename = "audited")]
+	pub audited: bool,
+}
+
+
+// StockItem ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct StockItem {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(rename = "status", alias = "inv:status")]
+	pub status: Option<String>,
+	#[serde(rename = "audited", alias = "inv:audited")]
+	pub inv_audited: Option<bool>,
+	#[serde(rename = "name", alias = "inv:name")]
+	pub name: String,
+	#[serde(rename = "comment")]
+	pub comment: Option<String>,
+	#[serde(rename = "warehouse", alias = "inv:warehouse")]
+	pub inv_warehouse: String,
+}
+
+
+// Inventory ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Inventory {
+	#[serde(rename = "item", alias = "inv:item")]
+	pub item: Vec<StockItem>,
+}
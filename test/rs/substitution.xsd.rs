@@ -0,0 +1,140 @@
+// Code generated by xgen. DO NOT EDIT.
+
+use serde::Serialize;
+use serde::Deserialize;
+
+use serde_xml_rs::from_reader;
+
+
+// ShapeType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShapeType {
+	#[serde(rename = "id")]
+	pub id: Option<String>,
+}
+
+
+// CircleType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CircleType {
+	#[serde(rename = "radius")]
+	pub radius: f64,
+}
+
+
+// SquareType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SquareType {
+	#[serde(rename = "side")]
+	pub side: f64,
+}
+
+
+// shape ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct shape {
+	#[serde(rename = "shape")]
+	pub shape: ShapeType,
+}
+
+
+// circle ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct circle {
+	#[serde(rename = "circle")]
+	pub circle: CircleType,
+}
+
+
+// square ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct square {
+	#[serde(rename = "square")]
+	pub square: SquareType,
+}
+
+
+// cube ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct cube {
+	#[serde(rename = "cube")]
+	pub cube: SquareType,
+}
+
+
+// ShapeSubstitution is an element in the substitution group of shape.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum ShapeSubstitution {
+	#[serde(rename = "circle")]
+	Circle(CircleType),
+	#[serde(rename = "square")]
+	Square(SquareType),
+	#[serde(rename = "cube")]
+	Cube(SquareType),
+}
+
+
+// Drawing ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Drawing {
+	#[serde(rename = "title")]
+	pub title: String,
+	#[serde(rename = "$value")]
+	pub shape: Vec<ShapeSubstitution>,
+}
+
+
+// color ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct color {
+	#[serde(rename = "color")]
+	pub color: String,
+}
+
+
+// red ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct red {
+	#[serde(rename = "red")]
+	pub red: String,
+}
+
+
+// blue ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct blue {
+	#[serde(rename = "blue")]
+	pub blue: String,
+}
+
+
+// ColorSubstitution is an element in the substitution group of color.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum ColorSubstitution {
+	#[serde(rename = "red")]
+	Red(String),
+	#[serde(rename = "blue")]
+	Blue(String),
+}
+
+
+// Palette ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Palette {
+	#[serde(rename = "$value")]
+	pub shape: ShapeSubstitution,
+	#[serde(rename = "$value")]
+	pub color: Option<ColorSubstitution>,
+}
+
+
+// Board ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Board {
+	#[serde(rename = "name")]
+	pub name: String,
+	#[serde(rename = "$value")]
+	pub shape: Vec<ShapeSubstitution>,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
@@ -0,0 +1,21 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ExtensionAttributes ...
+export class ExtensionAttributes {
+	VersionAttr: string | null;
+	AnyAttrs?: { [name: string]: string };
+}
+
+// ExtensionGroup ...
+export class ExtensionGroup {
+	Note: string;
+	Any?: Array<any>;
+}
+
+// Envelope ...
+export class Envelope {
+	IdAttr: string;
+	AnyAttrs?: { [name: string]: string };
+	Header: string;
+	Any?: Array<any>;
+}
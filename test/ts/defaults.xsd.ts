@@ -0,0 +1,53 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// SeatClass ...
+export enum SeatClass {
+	economy = 'economy',
+	business = 'business',
+}
+
+// AuditAttrs ...
+export class AuditAttrs {
+	SourceAttr: string | null;
+	RevisionAttr: number | null;
+}
+
+export const AuditAttrsDefaults: Partial<AuditAttrs> = {
+	SourceAttr: 'web',
+	RevisionAttr: 1,
+};
+
+// Seating ...
+export class Seating {
+	Seat: string;
+}
+
+export const SeatingDefaults: Partial<Seating> = {
+	Seat: 'economy',
+};
+
+// Ticket ...
+export class Ticket {
+	TnsAuditAttrs: AuditAttrs;
+	LangAttr?: string;
+	VersionAttr?: number;
+	ClassAttr?: string;
+	TnsSeating: Seating;
+	Holder: string;
+	Quantity: number;
+	Price?: number;
+	Refundable?: boolean;
+	Currency: string;
+	Stamp: string;
+}
+
+export const TicketDefaults: Partial<Ticket> = {
+	LangAttr: 'en',
+	VersionAttr: 2,
+	ClassAttr: 'business',
+	Quantity: 1,
+	Price: 9.5,
+	Refundable: false,
+	Currency: 'EUR',
+	Stamp: '12:00:00',
+};
@@ -0,0 +1,59 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// Amount ...
+export type Amount = number;
+
+// PositiveAmount ...
+export type PositiveAmount = number;
+
+// Price ...
+export class Price {
+	CurrencyAttr: string;
+	NoteAttr?: string;
+	Value: number;
+}
+
+// LocalPrice ...
+export class LocalPrice {
+	CurrencyAttr: string;
+	Value: number;
+}
+
+// TaxedPrice ...
+export class TaxedPrice extends Price  {
+	TaxAttr?: number;
+}
+
+// NetPrice ...
+export class NetPrice {
+	CurrencyAttr: string;
+	NoteAttr?: string;
+	TaxAttr: number;
+	Value: number;
+}
+
+// Fee ...
+export class Fee {
+	CurrencyAttr: string;
+	Value: number;
+}
+
+// SmallFee ...
+export class SmallFee {
+	CurrencyAttr: string;
+	Value: number;
+}
+
+// Item ...
+export class Item {
+	SkuAttr: string;
+	Name: string;
+	Price?: Price;
+	Comment?: string;
+}
+
+// Gift ...
+export class Gift {
+	SkuAttr: string;
+	Name: string;
+}
@@ -0,0 +1,35 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShirtColor is The available colors of a shirt.
+export enum ShirtColor {
+	red = 'red',
+	dark-blue = 'dark-blue',
+	light green = 'light green',
+}
+
+// ShirtSize ...
+export enum ShirtSize {
+	Enum1 = 1,
+	Enum2 = 2,
+	Enum3 = 3,
+}
+
+// Shirt ...
+export class Shirt {
+	SizeAttr: number;
+	FitAttr?: string;
+	Color: string;
+	Sleeve?: string;
+	AlternativeColor?: string;
+}
+
+// SleeveLength ...
+export enum SleeveLength {
+	short = 'short',
+	long = 'long',
+}
+
+// Wardrobe ...
+export class Wardrobe {
+	Shirt: Array<Shirt>;
+}
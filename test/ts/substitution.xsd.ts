@@ -0,0 +1,62 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShapeType ...
+export class ShapeType {
+	IdAttr?: string;
+}
+
+// CircleType ...
+export class CircleType {
+	Radius: number;
+}
+
+// SquareType ...
+export class SquareType {
+	Side: number;
+}
+
+// Shape ...
+export type Shape = ShapeType;
+
+// Circle ...
+export type Circle = CircleType;
+
+// Square ...
+export type Square = SquareType;
+
+// Cube ...
+export type Cube = SquareType;
+
+// ShapeSubstitution is an element in the substitution group of shape.
+export type ShapeSubstitution = CircleType | SquareType;
+
+// Drawing ...
+export class Drawing {
+	Title: string;
+	Shape: Array<ShapeSubstitution>;
+}
+
+// Color ...
+export type Color = string;
+
+// Red ...
+export type Red = string;
+
+// Blue ...
+export type Blue = string;
+
+// ColorSubstitution is an element in the substitution group of color.
+export type ColorSubstitution = string;
+
+// Palette ...
+export class Palette {
+	Shape: ShapeSubstitution;
+	Color?: ColorSubstitution;
+}
+
+// Board ...
+export class Board {
+	Name: string;
+	Shape: Array<ShapeSubstitution>;
+	Any?: Array<any>;
+}
@@ -0,0 +1,402 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/xuri/xgen/xsd"
+	"regexp"
+)
+
+// Sku is Stock keeping unit code.
+type Sku string
+
+var patternSku = regexp.MustCompile("^(?:[A-Z]{2}-\\p{Nd}+)$")
+
+// Validate checks the value of Sku against the facets of the XML schema.
+func (v Sku) Validate() error {
+	if n := len([]rune(string(v))); n < 5 {
+		return fmt.Errorf("Sku: length %d is less than 5", n)
+	}
+	if n := len([]rune(string(v))); n > 8 {
+		return fmt.Errorf("Sku: length %d is greater than 8", n)
+	}
+	if !patternSku.MatchString(string(v)) {
+		return fmt.Errorf("Sku: value %q does not match pattern %s", v, patternSku)
+	}
+	return nil
+}
+
+// Quantity ...
+type Quantity int
+
+// Validate checks the value of Quantity against the facets of the XML schema.
+func (v Quantity) Validate() error {
+	if int64(v) < 1 {
+		return fmt.Errorf("Quantity: value %v is less than 1", v)
+	}
+	if int64(v) >= 100 {
+		return fmt.Errorf("Quantity: value %v is greater than or equal to 100", v)
+	}
+	return nil
+}
+
+// Serial ...
+type Serial uint64
+
+// Validate checks the value of Serial against the facets of the XML schema.
+func (v Serial) Validate() error {
+	if uint64(v) < 9007199254740993 {
+		return fmt.Errorf("Serial: value %v is less than 9007199254740993", v)
+	}
+	if uint64(v) > 18446744073709551614 {
+		return fmt.Errorf("Serial: value %v is greater than 18446744073709551614", v)
+	}
+	return nil
+}
+
+// Offset ...
+type Offset int64
+
+// Validate checks the value of Offset against the facets of the XML schema.
+func (v Offset) Validate() error {
+	if int64(v) <= -9007199254740993 {
+		return fmt.Errorf("Offset: value %v is less than or equal to -9007199254740993", v)
+	}
+	return nil
+}
+
+// Since ...
+type Since string
+
+// Validate checks the value of Since against the facets of the XML schema.
+func (v Since) Validate() error {
+	if text, cmp, err := xsd.CompareText(v, "2000-01-01"); err != nil {
+		return fmt.Errorf("Since: %w", err)
+	} else if cmp < 0 {
+		return fmt.Errorf("Since: value %s is less than 2000-01-01", text)
+	}
+	return nil
+}
+
+// LeadTime ...
+type LeadTime string
+
+// Validate checks the value of LeadTime against the facets of the XML schema.
+func (v LeadTime) Validate() error {
+	if text, cmp, err := xsd.CompareText(v, "P30D"); err != nil {
+		return fmt.Errorf("LeadTime: %w", err)
+	} else if cmp > 0 {
+		return fmt.Errorf("LeadTime: value %s is greater than P30D", text)
+	}
+	return nil
+}
+
+// EvenCount ...
+type EvenCount int
+
+var patternEvenCount = regexp.MustCompile("^(?:\\p{Nd}*[02468])$")
+
+// Validate checks the value of EvenCount against the facets of the XML schema.
+func (v EvenCount) Validate() error {
+	if text, err := xsd.FormatText(v); err != nil {
+		return fmt.Errorf("EvenCount: %w", err)
+	} else if !patternEvenCount.MatchString(text) {
+		return fmt.Errorf("EvenCount: value %q does not match pattern %s", text, patternEvenCount)
+	}
+	return nil
+}
+
+// Currency ...
+type Currency string
+
+// Enumeration values of Currency.
+const (
+	CurrencyEUR Currency = "EUR"
+	CurrencyUSD Currency = "USD"
+)
+
+// Values returns the enumeration values of Currency.
+func (v Currency) Values() []Currency {
+	return []Currency{
+		CurrencyEUR,
+		CurrencyUSD,
+	}
+}
+
+// IsValid reports whether v is one of the enumeration values of Currency.
+func (v Currency) IsValid() bool {
+	for _, value := range v.Values() {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and rejects
+// values which are not one of the enumeration values of Currency.
+func (v *Currency) UnmarshalText(text []byte) error {
+	value := Currency(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid value %q for Currency", text)
+	}
+	*v = value
+	return nil
+}
+
+// Validate checks the value of Currency against the facets of the XML schema.
+func (v Currency) Validate() error {
+	if !v.IsValid() {
+		return fmt.Errorf("Currency: invalid value %v", v)
+	}
+	return nil
+}
+
+// Tag ...
+type Tag string
+
+var patternTag = regexp.MustCompile("^(?:[:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}][:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}\\-.0-9\\x{B7}\\x{300}-\\x{36F}\\x{203F}-\\x{2040}]*)$|^(?:#[\\x{0}-\\x{8}\\x{B}-\\x{C}\\x{E}-\\x{1F}!-\"$-\\x{7F}]+)$")
+
+// Validate checks the value of Tag against the facets of the XML schema.
+func (v Tag) Validate() error {
+	if !patternTag.MatchString(string(v)) {
+		return fmt.Errorf("Tag: value %q does not match pattern %s", v, patternTag)
+	}
+	return nil
+}
+
+// ShortTag ...
+type ShortTag string
+
+var patternShortTag = regexp.MustCompile("^(?:[:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}][:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}\\-.0-9\\x{B7}\\x{300}-\\x{36F}\\x{203F}-\\x{2040}]*)$|^(?:#[\\x{0}-\\x{8}\\x{B}-\\x{C}\\x{E}-\\x{1F}!-\"$-\\x{7F}]+)$")
+
+var patternShortTag2 = regexp.MustCompile("^(?:[^\\n\\r]{1,4})$")
+
+// Validate checks the value of ShortTag against the facets of the XML schema.
+func (v ShortTag) Validate() error {
+	if !patternShortTag.MatchString(string(v)) {
+		return fmt.Errorf("ShortTag: value %q does not match pattern %s", v, patternShortTag)
+	}
+	if !patternShortTag2.MatchString(string(v)) {
+		return fmt.Errorf("ShortTag: value %q does not match pattern %s", v, patternShortTag2)
+	}
+	return nil
+}
+
+// Price ...
+type Price struct {
+	CurrencyAttr Currency `xml:"currency,attr"`
+	Amount       float64  `xml:"amount"`
+}
+
+// Validate checks the fields of Price against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Price) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if err := v.CurrencyAttr.Validate(); err != nil {
+		return fmt.Errorf("Price.CurrencyAttr: %w", err)
+	}
+	if float64(v.Amount) <= 0 {
+		return fmt.Errorf("Price.Amount: value %v is less than or equal to 0", v.Amount)
+	}
+	if float64(v.Amount) > 10000 {
+		return fmt.Errorf("Price.Amount: value %v is greater than 10000", v.Amount)
+	}
+	return nil
+}
+
+// Audit ...
+type Audit struct {
+	XMLName    xml.Name `xml:"audit"`
+	AuthorAttr *string  `xml:"author,attr,omitempty"`
+}
+
+// Validate checks the fields of Audit against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Audit) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if v.AuthorAttr != nil {
+		if n := len([]rune(string(*v.AuthorAttr))); n > 10 {
+			return fmt.Errorf("Audit.AuthorAttr: length %d is greater than 10", n)
+		}
+	}
+	return nil
+}
+
+// Line ...
+type Line struct {
+	NumberAttr *Quantity `xml:"number,attr,omitempty"`
+	UnitAttr   *string   `xml:"unit,attr,omitempty"`
+	Sku        Sku       `xml:"sku"`
+	Quantity   Quantity  `xml:"quantity"`
+	Price      *Price    `xml:"price"`
+	Note       *string   `xml:"note,omitempty"`
+	Label      *string   `xml:"label,omitempty"`
+}
+
+// NewLine returns a new Line with the default values of the XML schema.
+func NewLine() *Line {
+	defaultUnitAttr := "pcs"
+	return &Line{
+		UnitAttr: &defaultUnitAttr,
+	}
+}
+
+var patternLineLabel = regexp.MustCompile("^(?:[:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}][:A-Z_a-z\\x{C0}-\\x{D6}\\x{D8}-\\x{F6}\\x{F8}-\\x{2FF}\\x{370}-\\x{37D}\\x{37F}-\\x{1FFF}\\x{200C}-\\x{200D}\\x{2070}-\\x{218F}\\x{2C00}-\\x{2FEF}\\x{3001}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFFD}\\x{10000}-\\x{EFFFF}\\-.0-9\\x{B7}\\x{300}-\\x{36F}\\x{203F}-\\x{2040}]*)$|^(?:#[\\x{0}-\\x{8}\\x{B}-\\x{C}\\x{E}-\\x{1F}!-\"$-\\x{7F}]+)$")
+
+var patternLineLabel2 = regexp.MustCompile("^(?:[^\\n\\r]{1,4})$")
+
+var patternLineLabel3 = regexp.MustCompile("^(?:[a-z]+)$")
+
+// Validate checks the fields of Line against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Line) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if v.NumberAttr != nil {
+		if err := v.NumberAttr.Validate(); err != nil {
+			return fmt.Errorf("Line.NumberAttr: %w", err)
+		}
+	}
+	if err := v.Sku.Validate(); err != nil {
+		return fmt.Errorf("Line.Sku: %w", err)
+	}
+	if err := v.Quantity.Validate(); err != nil {
+		return fmt.Errorf("Line.Quantity: %w", err)
+	}
+	if v.Price == nil {
+		return fmt.Errorf("Line: missing required element %q", "price")
+	}
+	if err := v.Price.Validate(); err != nil {
+		return fmt.Errorf("Line.Price: %w", err)
+	}
+	if v.Note != nil {
+		if n := len([]rune(string(*v.Note))); n > 20 {
+			return fmt.Errorf("Line.Note: length %d is greater than 20", n)
+		}
+	}
+	if v.Label != nil {
+		if !patternLineLabel.MatchString(string(*v.Label)) {
+			return fmt.Errorf("Line.Label: value %q does not match pattern %s", *v.Label, patternLineLabel)
+		}
+		if !patternLineLabel2.MatchString(string(*v.Label)) {
+			return fmt.Errorf("Line.Label: value %q does not match pattern %s", *v.Label, patternLineLabel2)
+		}
+		if !patternLineLabel3.MatchString(string(*v.Label)) {
+			return fmt.Errorf("Line.Label: value %q does not match pattern %s", *v.Label, patternLineLabel3)
+		}
+	}
+	if v.UnitAttr != nil && *v.UnitAttr != "pcs" {
+		return fmt.Errorf("Line.UnitAttr: value %v is not the fixed value %v", *v.UnitAttr, "pcs")
+	}
+	return nil
+}
+
+// Lines ...
+type Lines struct {
+	Line []*Line `xml:"line"`
+}
+
+// Validate checks the fields of Lines against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Lines) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if len(v.Line) == 0 {
+		return fmt.Errorf("Lines: missing required element %q", "line")
+	}
+	for _, item := range v.Line {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("Lines.Line: %w", err)
+		}
+	}
+	return nil
+}
+
+// Order ...
+type Order struct {
+	XMLName  xml.Name `xml:"http://example.org/validation Order"`
+	TnsAudit *Audit
+	Line     []*Line `xml:"line"`
+	Discount *Price  `xml:"discount,omitempty"`
+	Related  []Sku   `xml:"related,omitempty"`
+	Bundle   *Lines  `xml:"bundle,omitempty"`
+}
+
+// Validate checks the fields of Order against the facets and the required
+// elements of the XML schema, including the nested types.
+func (v *Order) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if err := v.TnsAudit.Validate(); err != nil {
+		return fmt.Errorf("Order.TnsAudit: %w", err)
+	}
+	if len(v.Line) == 0 {
+		return fmt.Errorf("Order: missing required element %q", "line")
+	}
+	for _, item := range v.Line {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("Order.Line: %w", err)
+		}
+	}
+	if err := v.Discount.Validate(); err != nil {
+		return fmt.Errorf("Order.Discount: %w", err)
+	}
+	for _, item := range v.Related {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("Order.Related: %w", err)
+		}
+	}
+	if err := v.Bundle.Validate(); err != nil {
+		return fmt.Errorf("Order.Bundle: %w", err)
+	}
+	if err := xsd.CheckIdentityConstraints(v,
+		xsd.IdentityConstraint{Kind: "key", Name: "lineSku", Selector: "tns:line", Fields: []string{"tns:sku"}},
+		xsd.IdentityConstraint{Kind: "unique", Name: "lineNumber", Selector: "tns:line", Fields: []string{"@number"}},
+		xsd.IdentityConstraint{Kind: "keyref", Name: "relatedLine", Refer: "lineSku", Selector: "tns:related", Fields: []string{"."}},
+		xsd.IdentityConstraint{Kind: "unique", Name: "bundleLine", Scope: "bundle", Selector: "tns:line", Fields: []string{"tns:sku"}},
+	); err != nil {
+		return fmt.Errorf("Order: %w", err)
+	}
+	return nil
+}
+
+// Batch ...
+type Batch *Lines
+
+// ValidateBatch checks the Batch element against the facets, the required
+// elements and the identity constraints of the XML schema.
+func ValidateBatch(v Batch) error {
+	if err := (*Lines)(v).Validate(); err != nil {
+		return err
+	}
+	if err := xsd.CheckIdentityConstraints(v,
+		xsd.IdentityConstraint{Kind: "unique", Name: "batchLine", Selector: ".//tns:line", Fields: []string{"tns:sku", "tns:price/@currency"}},
+	); err != nil {
+		return fmt.Errorf("Batch: %w", err)
+	}
+	return nil
+}
+
+// Code ...
+type Code string
+
+// Validate checks the value of Code against the facets of the XML schema.
+func (v Code) Validate() error {
+	if n := len([]rune(string(v))); n < 4 {
+		return fmt.Errorf("Code: length %d is less than 4", n)
+	}
+	if n := len([]rune(string(v))); n > 4 {
+		return fmt.Errorf("Code: length %d is greater than 4", n)
+	}
+	return nil
+}
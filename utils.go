@@ -10,8 +10,6 @@ package xgen
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -24,10 +22,9 @@ import (
 )
 
 var (
-	copyright      = "// Code generated by xgen. DO NOT EDIT."
-	matchFirstCap  = regexp.MustCompile("([A-Z])([A-Z][a-z])")
-	matchAllCap    = regexp.MustCompile("([a-z0-9])([A-Z])")
-	fieldNameCount map[string]int
+	copyright     = "// Code generated by xgen. DO NOT EDIT."
+	matchFirstCap = regexp.MustCompile("([A-Z])([A-Z][a-z])")
+	matchAllCap   = regexp.MustCompile("([a-z0-9])([A-Z])")
 )
 
 // ToSnakeCase converts the provided string to snake_case.
@@ -116,7 +113,7 @@ var BuildInTypes = map[string][]string{
 	"positiveInteger":    {"int", "number", "int", "Integer", "u32"},
 	"short":              {"int16", "number", "int", "Integer", "i16"},
 	"string":             {"string", "string", "char", "String", "String"},
-	"time":               {"time.Time", "string", "char", "String", "String"},
+	"time":               {"string", "string", "char", "String", "String"},
 	"token":              {"string", "string", "char", "String", "String"},
 	"unsignedByte":       {"uint8", "any", "char", "Byte", "u8"},
 	"unsignedInt":        {"uint32", "number", "unsigned int", "Integer", "u32"},
@@ -128,6 +125,30 @@ var BuildInTypes = map[string][]string{
 	"xml:id":             {"string", "string", "char", "String", "String"},
 }
 
+// GoXSDTimeTypes defines the Go types of the xsd runtime package for the date
+// and time data types in XSD, which are used instead of the BuildInTypes with
+// the XSDTimeTypes option.
+var GoXSDTimeTypes = map[string]string{
+	"date":       "xsd.Date",
+	"dateTime":   "xsd.DateTime",
+	"duration":   "xsd.Duration",
+	"gDay":       "xsd.GDay",
+	"gMonth":     "xsd.GMonth",
+	"gMonthDay":  "xsd.GMonthDay",
+	"gYear":      "xsd.GYear",
+	"gYearMonth": "xsd.GYearMonth",
+	"time":       "xsd.Time",
+}
+
+// buildInType returns the data type of the language for the built-in data
+// type in XSD.
+func (opt *Options) buildInType(value string) (string, bool) {
+	if buildType, ok := GoXSDTimeTypes[value]; ok && opt.XSDTimeTypes && opt.Lang == "Go" {
+		return buildType, true
+	}
+	return getBuildInTypeByLang(value, opt.Lang)
+}
+
 func getBuildInTypeByLang(value, lang string) (buildType string, ok bool) {
 	supportLang := map[string]int{
 		"Go":         0,
@@ -164,6 +185,17 @@ func getBasefromSimpleType(name string, XSDSchema []interface{}) string {
 	return name
 }
 
+// getSimpleType returns the named simple type declared in the given proto
+// tree, or nil if it doesn't exist.
+func getSimpleType(name string, XSDSchema []interface{}) *SimpleType {
+	for _, ele := range XSDSchema {
+		if v, ok := ele.(*SimpleType); ok && v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
 func getNSPrefix(str string) (ns string) {
 	split := strings.Split(str, ":")
 	if len(split) == 2 {
@@ -235,25 +267,6 @@ func isValidURL(toTest string) bool {
 	return true
 }
 
-func fetchSchema(URL string) ([]byte, error) {
-	var body []byte
-	var client http.Client
-	var err error
-	resp, err := client.Get(URL)
-	if err != nil {
-		return body, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusOK {
-		body, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return body, err
-		}
-	}
-	return body, err
-}
-
 func genFieldComment(name, doc, prefix string) string {
 	docReplacer := strings.NewReplacer("\n", fmt.Sprintf("\r\n%s ", prefix), "\t", "")
 	if doc == "" {
@@ -262,6 +275,32 @@ func genFieldComment(name, doc, prefix string) string {
 	return fmt.Sprintf("\r\n%s %s is %s\r\n", prefix, name, docReplacer.Replace(doc))
 }
 
+// genTypeComment returns the comment of the type, which is followed by the
+// XPath test expressions of the assertions on it.
+func genTypeComment(name, doc string, assertions []Assertion, prefix string) string {
+	comment := genFieldComment(name, doc, prefix)
+	for _, assertion := range assertions {
+		comment += fmt.Sprintf("%s Assert: %s\r\n", prefix, strings.Join(strings.Fields(assertion.Test), " "))
+	}
+	return comment
+}
+
+// genAlternativeComment returns the comment of the field of the element,
+// which lists the types selected by the type alternatives of its declaration.
+func (gen *CodeGenerator) genAlternativeComment(element *Element, prefix string) (comment string) {
+	decl := gen.alternativeDeclaration(element)
+	if decl == nil {
+		return
+	}
+	for _, alternative := range decl.Alternatives {
+		if alternative.Test == "" {
+			return comment + fmt.Sprintf("\t%s %s has the type %s otherwise.\n", prefix, element.Name, alternative.TypeName.Local)
+		}
+		comment += fmt.Sprintf("\t%s %s has the type %s if %s.\n", prefix, element.Name, alternative.TypeName.Local, strings.Join(strings.Fields(alternative.Test), " "))
+	}
+	return
+}
+
 type kvPair struct {
 	key   string
 	value string
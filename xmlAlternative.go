@@ -0,0 +1,49 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// OnAlternative handles parsing event on the alternative start elements. The
+// alternative element of XSD 1.1 assigns the type to the declaring element,
+// if the XPath expression of the test on its attributes is true. The
+// alternatives with an anonymous simpleType or complexType instead of the
+// type attribute are not supported, and return an error rather than falling
+// back to the declared type of the element.
+func (opt *Options) OnAlternative(ele xml.StartElement, protoTree []interface{}) (err error) {
+	var alternative Alternative
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			alternative.Test = attr.Value
+		}
+		if attr.Name.Local == "type" {
+			alternative.TypeName = opt.qualifiedName(attr.Value)
+			if alternative.Type, err = opt.getFieldValueType(attr.Value, protoTree); err != nil {
+				return
+			}
+		}
+	}
+	if alternative.TypeName.Local == "" {
+		return errors.New("anonymous type of alternative is not supported")
+	}
+	if alternative.Type == "" {
+		return
+	}
+	if hook, ok := opt.Hook.(AssertionHook); ok {
+		var next bool
+		if next, err = hook.OnAlternative(opt, &alternative); err != nil || !next {
+			return
+		}
+	}
+	opt.alternatives = append(opt.alternatives, alternative)
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// OnAny handles parsing event on the any start elements. The any element
+// enables the author to extend the XML document with elements not specified
+// by the schema, which are matched by the element wildcard.
+func (opt *Options) OnAny(ele xml.StartElement, protoTree []interface{}) (err error) {
+	e := Element{Wildcard: true, Namespace: "##any", ProcessContents: "strict"}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "namespace" {
+			e.Namespace = attr.Value
+		}
+		if attr.Name.Local == "processContents" {
+			e.ProcessContents = attr.Value
+		}
+		if attr.Name.Local == "maxOccurs" {
+			var maxOccurs int
+			if maxOccurs, err = strconv.Atoi(attr.Value); attr.Value != "unbounded" && err != nil {
+				return
+			}
+			if attr.Value == "unbounded" || maxOccurs > 1 {
+				e.Plural, err = true, nil
+			}
+		}
+		if attr.Name.Local == "minOccurs" {
+			var minOccurs int
+			if minOccurs, err = strconv.Atoi(attr.Value); err != nil {
+				return
+			}
+			if minOccurs == 0 {
+				e.Optional = true
+			}
+		}
+	}
+
+	if len(opt.InPluralSequence) > 0 && opt.InPluralSequence[len(opt.InPluralSequence)-1] {
+		e.Plural = true
+	}
+	if opt.Choice.Len() > 0 {
+		e.Optional = true
+		e.Plural = e.Plural || opt.Choice.Peek().(*Choice).Plural
+	}
+
+	if opt.ComplexType.Len() > 0 {
+		opt.ComplexType.Peek().(*ComplexType).Elements = addWildcard(opt.ComplexType.Peek().(*ComplexType).Elements, e)
+		return
+	}
+	if opt.InGroup > 0 && opt.Group.Len() > 0 {
+		opt.Group.Peek().(*Group).Elements = addWildcard(opt.Group.Peek().(*Group).Elements, e)
+	}
+	return
+}
+
+// addWildcard adds the element wildcard to the elements. The multiple
+// wildcards in the same content model are merged into a plural one, since
+// the matched elements are collected in one field of the generated types.
+func addWildcard(elements []Element, e Element) []Element {
+	for i := range elements {
+		if elements[i].Wildcard {
+			elements[i].Plural = true
+			elements[i].Optional = elements[i].Optional && e.Optional
+			return elements
+		}
+	}
+	return append(elements, e)
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssert handles parsing event on the assert start elements. The assert
+// element of XSD 1.1 specifies the XPath expression, which must be true for
+// the valid elements of the complex type.
+func (opt *Options) OnAssert(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.ComplexType.Len() == 0 {
+		return
+	}
+	var assertion Assertion
+	if assertion, err = opt.newAssertion(ele); err != nil || assertion.Test == "" {
+		return
+	}
+	c := opt.ComplexType.Peek().(*ComplexType)
+	c.Assertions = append(c.Assertions, assertion)
+	return
+}
+
+// newAssertion returns the assertion of the assert element or the assertion
+// facet, it returns an empty assertion if it is dropped by the hook.
+func (opt *Options) newAssertion(ele xml.StartElement) (assertion Assertion, err error) {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			assertion.Test = attr.Value
+		}
+		if attr.Name.Local == "xpathDefaultNamespace" {
+			assertion.XPathDefaultNamespace = attr.Value
+		}
+	}
+	if hook, ok := opt.Hook.(AssertionHook); ok && assertion.Test != "" {
+		var next bool
+		if next, err = hook.OnAssertion(opt, &assertion); err != nil || !next {
+			return Assertion{}, err
+		}
+	}
+	return
+}
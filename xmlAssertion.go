@@ -0,0 +1,27 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssertion handles parsing event on the assertion start elements. The
+// assertion facet of XSD 1.1 specifies the XPath expression on the $value,
+// which must be true for the valid values of the simple type.
+func (opt *Options) OnAssertion(ele xml.StartElement, protoTree []interface{}) (err error) {
+	r := opt.currentRestriction()
+	if r == nil {
+		return
+	}
+	var assertion Assertion
+	if assertion, err = opt.newAssertion(ele); err != nil || assertion.Test == "" {
+		return
+	}
+	r.Assertions = append(r.Assertions, assertion)
+	return
+}
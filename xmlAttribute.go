@@ -16,9 +16,11 @@ func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (
 	attribute := Attribute{
 		Optional: true,
 	}
+	var form string
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
 			attribute.Name = attr.Value
+			attribute.Ref = opt.qualifiedName(attr.Value)
 			attribute.Type, err = opt.GetValueType(attr.Value, protoTree)
 			if err != nil {
 				return
@@ -28,17 +30,29 @@ func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (
 			attribute.Name = attr.Value
 		}
 		if attr.Name.Local == "type" {
-			attribute.Type, err = opt.GetValueType(attr.Value, protoTree)
+			attribute.TypeName = opt.qualifiedName(attr.Value)
+			attribute.Type, err = opt.getFieldValueType(attr.Value, protoTree)
 			if err != nil {
 				return
 			}
 		}
+		if attr.Name.Local == "default" {
+			attribute.Default = attr.Value
+		}
+		if attr.Name.Local == "fixed" {
+			attribute.Default, attribute.Fixed = attr.Value, true
+		}
+		if attr.Name.Local == "form" {
+			form = attr.Value
+		}
 		if attr.Name.Local == "use" {
 			if attr.Value == "required" {
 				attribute.Optional = false
 			}
+			attribute.Prohibited = attr.Value == "prohibited"
 		}
 	}
+	attribute.TargetNamespace = opt.namespace(attribute.Ref, form, opt.Schema.AttributeFormDefault, opt.ComplexType.Len() > 0 || opt.AttributeGroup.Len() > 0)
 	opt.Attribute.Push(&attribute)
 	return
 }
@@ -22,6 +22,7 @@ func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface
 		}
 		if attr.Name.Local == "ref" {
 			attributeGroup.Name = attr.Value
+			attributeGroup.RefName = opt.qualifiedName(attr.Value)
 			attributeGroup.Ref, err = opt.GetValueType(attr.Value, protoTree)
 			if err != nil {
 				return
@@ -29,8 +30,10 @@ func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface
 		}
 	}
 	if opt.ComplexType.Len() == 0 {
-		opt.InAttributeGroup = true
-		opt.CurrentEle = opt.InElement
+		if opt.AttributeGroup.Len() == 0 {
+			opt.InAttributeGroup = true
+			opt.CurrentEle = opt.InElement
+		}
 		opt.AttributeGroup.Push(&attributeGroup)
 		return
 	}
@@ -44,6 +47,13 @@ func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface
 
 // EndAttributeGroup handles parsing event on the attributeGroup end elements.
 func (opt *Options) EndAttributeGroup(ele xml.EndElement, protoTree []interface{}) (err error) {
+	if opt.AttributeGroup.Len() > 1 {
+		// the attribute groups referenced in the attribute group are merged
+		// into it after parsing
+		ref := opt.AttributeGroup.Pop().(*AttributeGroup)
+		opt.AttributeGroup.Peek().(*AttributeGroup).AttributeGroup = append(opt.AttributeGroup.Peek().(*AttributeGroup).AttributeGroup, *ref)
+		return
+	}
 	if opt.AttributeGroup.Len() > 0 {
 		opt.ProtoTree = append(opt.ProtoTree, opt.AttributeGroup.Pop())
 		opt.CurrentEle = ""
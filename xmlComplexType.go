@@ -8,22 +8,34 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+
+	"golang.org/x/net/html/charset"
+)
 
 // OnComplexType handles parsing event on the complex start elements. A
 // complex element contains other elements and/or attributes.
 func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{}) (err error) {
 	if opt.ComplexType.Len() > 0 {
 		e := opt.Element.Pop().(*Element)
+		parent := opt.ComplexType.Peek().(*ComplexType)
 		opt.ComplexType.Push(&ComplexType{
-			Doc:  e.Doc,
-			Name: e.Name,
+			Doc:       e.Doc,
+			Name:      opt.anonymousTypeName(parent.Name, e, parent.Elements),
+			Anonymous: true,
 		})
 	}
 
 	if opt.ComplexType.Len() == 0 {
 		c := ComplexType{}
-		opt.CurrentEle = opt.InElement
+		// the anonymous complex types in the groups keep the group as the
+		// current element until the end of the group
+		if opt.InGroup == 0 {
+			opt.CurrentEle = opt.InElement
+		}
 		for _, attr := range ele.Attr {
 			if attr.Name.Local == "name" {
 				c.Name = attr.Value
@@ -32,8 +44,13 @@ func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{})
 		if opt.Element.Len() > 0 {
 			e := opt.Element.Pop().(*Element)
 			c.Doc = e.Doc
-			if c.Name == "" {
-				c.Name = e.Name
+			switch {
+			case c.Name != "":
+			case opt.InGroup > 0 && opt.Group.Len() > 0:
+				group := opt.Group.Peek().(*Group)
+				c.Name, c.Anonymous = opt.anonymousTypeName(group.Name, e, group.Elements), true
+			default:
+				c.Name, c.Element, c.Anonymous = e.Name, true, true
 			}
 		}
 		opt.ComplexType.Push(&c)
@@ -41,9 +58,66 @@ func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{})
 	return
 }
 
+// anonymousTypeName returns the name of the anonymous complex type of the
+// local element, which is derived from the path of the element, such as
+// OrderLineItemPrice for the price element of the lineItem element of the
+// Order element. A numeric suffix is added to the name if it's the name of a
+// top-level component or another anonymous type of the document. The type of
+// the element added to the elements of the parent is set to the name.
+func (opt *Options) anonymousTypeName(parent string, e *Element, elements []Element) string {
+	name := parent + MakeFirstUpperCase(e.Name)
+	for i := 2; opt.typeNames[MakeFirstUpperCase(name)]; i++ {
+		name = fmt.Sprintf("%s%s%d", parent, MakeFirstUpperCase(e.Name), i)
+	}
+	if opt.typeNames == nil {
+		opt.typeNames = make(map[string]bool)
+	}
+	opt.typeNames[MakeFirstUpperCase(name)] = true
+	for i := len(elements) - 1; i >= 0; i-- {
+		if elements[i].Name == e.Name {
+			elements[i].Type = name
+			break
+		}
+	}
+	e.Type = name
+	return name
+}
+
+// declaredTypeNames returns the names of the top-level components of the
+// schema document, which are the names of the generated types. The names are
+// collected before parsing the document, so that the names derived for the
+// anonymous types don't hide the components declared after them.
+func declaredTypeNames(data []byte) map[string]bool {
+	names := make(map[string]bool)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	var depth int
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return names
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if depth++; depth != 2 {
+				continue
+			}
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "name" {
+					names[MakeFirstUpperCase(attr.Value)] = true
+				}
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
 // EndComplexType handles parsing event on the complex end elements.
 func (opt *Options) EndComplexType(ele xml.EndElement, protoTree []interface{}) (err error) {
 	opt.ProtoTree = append(opt.ProtoTree, opt.ComplexType.Pop())
-	opt.CurrentEle = ""
+	if opt.InGroup == 0 {
+		opt.CurrentEle = ""
+	}
 	return
 }
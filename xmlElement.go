@@ -16,9 +16,11 @@ import (
 // OnElement handles parsing event on the element start elements.
 func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (err error) {
 	e := Element{}
+	var form string
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
 			e.Name = attr.Value
+			e.Ref = opt.qualifiedName(attr.Value)
 			e.Type, err = opt.GetValueType(attr.Value, protoTree)
 			if err != nil {
 				return
@@ -29,11 +31,27 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 			e.Name = attr.Value
 		}
 		if attr.Name.Local == "type" {
-			e.Type, err = opt.GetValueType(attr.Value, protoTree)
+			e.TypeName = opt.qualifiedName(attr.Value)
+			e.Type, err = opt.getFieldValueType(attr.Value, protoTree)
 			if err != nil {
 				return
 			}
 		}
+		if attr.Name.Local == "default" {
+			e.Default = attr.Value
+		}
+		if attr.Name.Local == "fixed" {
+			e.Default, e.Fixed = attr.Value, true
+		}
+		if attr.Name.Local == "form" {
+			form = attr.Value
+		}
+		if attr.Name.Local == "abstract" {
+			e.Abstract = attr.Value == "true" || attr.Value == "1"
+		}
+		if attr.Name.Local == "substitutionGroup" {
+			e.SubstitutionGroup = opt.qualifiedName(attr.Value)
+		}
 		if attr.Name.Local == "maxOccurs" {
 			var maxOccurs int
 			if maxOccurs, err = strconv.Atoi(attr.Value); attr.Value != "unbounded" && err != nil {
@@ -62,6 +80,7 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 	if len(opt.InPluralSequence) > 0 && opt.InPluralSequence[len(opt.InPluralSequence)-1] {
 		e.Plural = true
 	}
+	e.TargetNamespace = opt.namespace(e.Ref, form, opt.Schema.ElementFormDefault, opt.ComplexType.Len() > 0 || opt.InGroup > 0)
 
 	alreadyPushedElement := false
 	if e.Type == "" {
@@ -111,6 +130,15 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 
 // EndElement handles parsing event on the element end elements.
 func (opt *Options) EndElement(ele xml.EndElement, protoTree []interface{}) (err error) {
+	if len(opt.alternatives) > 0 {
+		if e := opt.endingElement(); e != nil {
+			e.Alternatives = append(e.Alternatives, opt.alternatives...)
+		}
+		opt.alternatives = nil
+	}
+	if len(opt.identityConstraints) > 0 {
+		opt.addIdentityConstraints()
+	}
 	if opt.Element.Len() == 0 {
 		return
 	}
@@ -124,9 +152,31 @@ func (opt *Options) EndElement(ele xml.EndElement, protoTree []interface{}) (err
 	return
 }
 
+// endingElement returns the element whose end element is being handled, or
+// nil for the top-level element with an anonymous complex type. The local
+// elements are the last ones added to the complex type or group.
+func (opt *Options) endingElement() *Element {
+	var elements []Element
+	switch {
+	case opt.ComplexType.Len() > 0:
+		elements = opt.ComplexType.Peek().(*ComplexType).Elements
+	case opt.InGroup > 0 && opt.Group.Len() > 0:
+		elements = opt.Group.Peek().(*Group).Elements
+	case opt.Element.Len() > 0:
+		return opt.Element.Peek().(*Element)
+	}
+	if len(elements) > 0 {
+		return &elements[len(elements)-1]
+	}
+	return nil
+}
+
 func findElement(element *Element, elements []Element) (existing *Element, index int) {
-	for i, ele := range elements {
-		if element.Name == ele.Name {
+	for i := range elements {
+		// compare by index, copying every element in the loop is slow for
+		// the complex types with many elements
+		if element.Name == elements[i].Name {
+			ele := elements[i]
 			return &ele, i
 		}
 	}
@@ -14,8 +14,8 @@ import "encoding/xml"
 func (opt *Options) OnEnumeration(ele xml.StartElement, protoTree []interface{}) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "value" {
-			if opt.SimpleType.Peek() != nil {
-				opt.SimpleType.Peek().(*SimpleType).Restriction.Enum = append(opt.SimpleType.Peek().(*SimpleType).Restriction.Enum, attr.Value)
+			if restriction := opt.currentRestriction(); restriction != nil {
+				restriction.Enum = append(restriction.Enum, attr.Value)
 			}
 		}
 	}
@@ -22,6 +22,8 @@ func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (
 			}
 			if opt.ComplexType.Peek() != nil {
 				complexType := opt.ComplexType.Peek().(*ComplexType)
+				complexType.BaseName = opt.qualifiedName(attr.Value)
+				complexType.Derivation = "extension"
 				complexType.Base, err = opt.GetValueType(valueType, protoTree)
 				if err != nil {
 					return
@@ -38,7 +40,7 @@ func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (
 // EndExtension handles parsing event on the extension end elements.
 func (opt *Options) EndExtension(ele xml.EndElement, protoTree []interface{}) (err error) {
 	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
+		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Attribute.Peek().(*Attribute).Restriction), opt.ProtoTree)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,27 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnField handles parsing event on the field start elements. The field
+// element specifies the XPath expression, which selects the value of the
+// selected elements restricted by the identity constraint.
+func (opt *Options) OnField(ele xml.StartElement, protoTree []interface{}) (err error) {
+	c, ok := opt.IdentityConstraint.Peek().(*IdentityConstraint)
+	if !ok {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "xpath" {
+			c.Fields = append(c.Fields, attr.Value)
+		}
+	}
+	return
+}
@@ -8,15 +8,26 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// OnFractionDigits handles parsing event on the fractionDigits start elements.
+func (opt *Options) OnFractionDigits(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if restriction := opt.currentRestriction(); restriction != nil {
+		restriction.Precision, err = strconv.Atoi(getFacetValue(ele))
+	}
+	return
+}
 
 // EndFractionDigits handles parsing event on the fractionDigits end elements.
 // Enumeration Defines a list of acceptable values. FractionDigits specifies
 // the maximum number of decimal places allowed. Must be equal to or greater
 // than zero.
 func (opt *Options) EndFractionDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
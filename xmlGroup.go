@@ -21,6 +21,7 @@ func (opt *Options) OnGroup(ele xml.StartElement, protoTree []interface{}) (err
 		}
 		if attr.Name.Local == "ref" {
 			group.Name = attr.Value
+			group.RefName = opt.qualifiedName(attr.Value)
 			group.Ref, err = opt.GetValueType(attr.Value, protoTree)
 			if err != nil {
 				return
@@ -15,5 +15,15 @@ import "encoding/xml"
 // data type.
 func (opt *Options) OnImport(ele xml.StartElement, protoTree []interface{}) (err error) {
 	opt.prepareNSSchemaLocationMap(ele)
+	ref := schemaRef{}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "namespace" {
+			ref.namespace = attr.Value
+		}
+		if attr.Name.Local == "schemaLocation" {
+			ref.location = attr.Value
+		}
+	}
+	opt.Schema.refs = append(opt.Schema.refs, ref)
 	return
 }
@@ -16,6 +16,7 @@ import "encoding/xml"
 func (opt *Options) OnInclude(ele xml.StartElement, protoTree []interface{}) (err error) {
 	for _, ele := range ele.Attr {
 		if ele.Name.Local == "schemaLocation" {
+			opt.Schema.refs = append(opt.Schema.refs, schemaRef{location: ele.Value, include: true})
 			if _, ok := opt.IncludeMap[ele.Value]; ok {
 				continue
 			}
@@ -0,0 +1,65 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnKey handles parsing event on the key start elements. The key element
+// specifies that the values of the fields must be unique and present among
+// the elements selected in the scope of the declaring element.
+func (opt *Options) OnKey(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.onIdentityConstraint(ele)
+	return
+}
+
+// EndKey handles parsing event on the key end elements.
+func (opt *Options) EndKey(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
+
+// onIdentityConstraint pushes the key, keyref or unique constraint onto the
+// stack, to collect the selector and fields of it.
+func (opt *Options) onIdentityConstraint(ele xml.StartElement) {
+	c := IdentityConstraint{Kind: ele.Name.Local}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "name" {
+			c.Name = attr.Value
+		}
+		if attr.Name.Local == "refer" {
+			c.Refer = opt.qualifiedName(attr.Value)
+		}
+	}
+	opt.IdentityConstraint.Push(&c)
+}
+
+// endIdentityConstraint pops the identity constraint, which is added to the
+// declaring element at the end of it.
+func (opt *Options) endIdentityConstraint() {
+	if c, ok := opt.IdentityConstraint.Pop().(*IdentityConstraint); ok {
+		opt.identityConstraints = append(opt.identityConstraints, *c)
+	}
+}
+
+// addIdentityConstraints adds the identity constraints declared in the
+// ending element to it. The top-level elements with an anonymous complex type
+// are represented by the complex type.
+func (opt *Options) addIdentityConstraints() {
+	constraints := opt.identityConstraints
+	opt.identityConstraints = nil
+	if e := opt.endingElement(); e != nil {
+		e.IdentityConstraints = append(e.IdentityConstraints, constraints...)
+		return
+	}
+	if len(opt.ProtoTree) > 0 {
+		if c, ok := opt.ProtoTree[len(opt.ProtoTree)-1].(*ComplexType); ok {
+			c.IdentityConstraints = append(c.IdentityConstraints, constraints...)
+		}
+	}
+}
@@ -10,12 +10,17 @@ package xgen
 
 import "encoding/xml"
 
+// OnLength handles parsing event on the length start elements.
+func (opt *Options) OnLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.onLengthFacet(ele, true, true)
+}
+
 // EndLength handles parsing event on the length end elements. Length
 // specifies the exact number of characters or list items allowed. Must be
 // equal to or greater than zero.
 func (opt *Options) EndLength(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
@@ -10,12 +10,17 @@ package xgen
 
 import "encoding/xml"
 
+// OnMaxInclusive handles parsing event on the maxInclusive start elements.
+func (opt *Options) OnMaxInclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.onBoundFacet(ele, true, false)
+}
+
 // EndMaxInclusive handles parsing event on the maxInclusive end elements.
 // MaxInclusive specifies the upper bounds for numeric values (the value must
 // be less than or equal to this value).
 func (opt *Options) EndMaxInclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
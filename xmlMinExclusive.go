@@ -10,12 +10,17 @@ package xgen
 
 import "encoding/xml"
 
+// OnMinExclusive handles parsing event on the minExclusive start elements.
+func (opt *Options) OnMinExclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.onBoundFacet(ele, false, true)
+}
+
 // EndMinExclusive handles parsing event on the minExclusive end elements.
 // MinExclusive specifies the lower bounds for numeric values (the value must
 // be greater than this value).
 func (opt *Options) EndMinExclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
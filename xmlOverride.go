@@ -0,0 +1,19 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnOverride handles parsing event on the override start elements. The
+// override element of XSD 1.1 includes the schema file like the include
+// element, and replaces the components declared in it with the components of
+// the same name declared in the override element.
+func (opt *Options) OnOverride(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.OnInclude(ele, protoTree)
+}
@@ -0,0 +1,20 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnRedefine handles parsing event on the redefine start elements. The
+// redefine element includes the schema file like the include element, and
+// redefines the simple and complex types, groups, and attribute groups
+// declared in it. The redefinitions are declared in terms of themselves,
+// which are resolved to the original components after parsing.
+func (opt *Options) OnRedefine(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.OnInclude(ele, protoTree)
+}
@@ -8,12 +8,22 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/xgen/xsd"
+)
 
 // OnRestriction handles parsing event on the restriction start elements. The
 // restriction element defines restrictions on a simpleType, simpleContent, or
 // complexContent definition.
 func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.patternStep = false
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "base" {
 			var valueType string
@@ -22,8 +32,15 @@ func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{})
 				return
 			}
 			if opt.SimpleType.Peek() != nil {
+				// the values of the derived type match the patterns of the
+				// base type as well
+				if base := opt.simpleType(trimNSPrefix(attr.Value), protoTree); base != nil {
+					restriction := &opt.SimpleType.Peek().(*SimpleType).Restriction
+					restriction.Patterns = append(append([]*regexp.Regexp{}, base.Restriction.Patterns...), restriction.Patterns...)
+				}
 				if opt.Element.Len() > 0 {
 					opt.Element.Peek().(*Element).Type, err = opt.GetValueType(valueType, protoTree)
+					opt.SimpleType.Peek().(*SimpleType).Base = opt.Element.Peek().(*Element).Type
 					return
 				}
 
@@ -34,6 +51,16 @@ func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{})
 				if opt.SimpleType.Peek().(*SimpleType).Name == "" {
 					opt.SimpleType.Peek().(*SimpleType).Name = attr.Value
 				}
+				return
+			}
+			if opt.ComplexType.Peek() != nil {
+				// the particles of the complex types derived by restriction
+				// are declared in the restriction, the attributes and the
+				// value of the simple content are inherited from the base
+				// type after parsing
+				complexType := opt.ComplexType.Peek().(*ComplexType)
+				complexType.BaseName = opt.qualifiedName(attr.Value)
+				complexType.Derivation = "restriction"
 			}
 		}
 	}
@@ -43,12 +70,18 @@ func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{})
 // EndRestriction handles parsing event on the restriction end elements.
 func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{}) (err error) {
 	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
+		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Attribute.Peek().(*Attribute).Restriction), opt.ProtoTree)
 		if err != nil {
 			return
 		}
 		opt.CurrentEle = ""
 	}
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 && !opt.InUnion {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
+			return
+		}
+		opt.CurrentEle = ""
+	}
 	if !opt.Element.Empty() {
 		if !opt.ComplexType.Empty() && len(opt.ComplexType.Peek().(*ComplexType).Elements) > 0 {
 			opt.ComplexType.Peek().(*ComplexType).Elements[len(opt.ComplexType.Peek().(*ComplexType).Elements)-1] = *opt.Element.Peek().(*Element)
@@ -56,3 +89,152 @@ func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{})
 	}
 	return
 }
+
+// currentRestriction returns the restriction which the facets are applied to.
+// The facets of an anonymous simple type are kept on the element or attribute
+// which declares it, once the simple type has been popped.
+func (opt *Options) currentRestriction() *Restriction {
+	if opt.SimpleType.Peek() != nil {
+		return &opt.SimpleType.Peek().(*SimpleType).Restriction
+	}
+	if opt.Attribute.Peek() != nil {
+		return &opt.Attribute.Peek().(*Attribute).Restriction
+	}
+	if opt.Element.Peek() != nil {
+		return &opt.Element.Peek().(*Element).Restriction
+	}
+	return nil
+}
+
+// popSimpleType pops the anonymous simple type declared in an element or
+// attribute and returns its base type. The facets of the simple type are
+// merged into the given restriction.
+func (opt *Options) popSimpleType(restriction *Restriction) string {
+	simpleType := opt.SimpleType.Pop().(*SimpleType)
+	mergeRestriction(restriction, &simpleType.Restriction)
+	return simpleType.Base
+}
+
+// mergeRestriction copies the specified facets from src to dst.
+func mergeRestriction(dst, src *Restriction) {
+	if src.Doc != "" {
+		dst.Doc = src.Doc
+	}
+	if src.Precision != 0 {
+		dst.Precision = src.Precision
+	}
+	if src.TotalDigits != 0 {
+		dst.TotalDigits = src.TotalDigits
+	}
+	dst.Enum = append(dst.Enum, src.Enum...)
+	if src.HasMin {
+		dst.Min, dst.HasMin, dst.MinExclusive = src.Min, true, src.MinExclusive
+	}
+	if src.HasMax {
+		dst.Max, dst.HasMax, dst.MaxExclusive = src.Max, true, src.MaxExclusive
+	}
+	if src.HasMinLength {
+		dst.MinLength, dst.HasMinLength = src.MinLength, true
+	}
+	if src.HasMaxLength {
+		dst.MaxLength, dst.HasMaxLength = src.MaxLength, true
+	}
+	dst.Patterns = append(dst.Patterns, src.Patterns...)
+	dst.Assertions = append(dst.Assertions, src.Assertions...)
+}
+
+// hasFacets returns true if any constraining facet is specified in the
+// restriction.
+func (r *Restriction) hasFacets() bool {
+	return len(r.Enum) > 0 || r.HasMin || r.HasMax || r.HasMinLength || r.HasMaxLength || len(r.Patterns) > 0
+}
+
+// getFacetValue returns the value attribute of the given facet element.
+func getFacetValue(ele xml.StartElement) string {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "value" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// onBoundFacet handles the minInclusive, maxInclusive, minExclusive and
+// maxExclusive facets. The bounds are kept as the lexical values, which are
+// the numbers, or the dates, times and durations of the ordered date and
+// time types.
+func (opt *Options) onBoundFacet(ele xml.StartElement, isMax, exclusive bool) error {
+	restriction := opt.currentRestriction()
+	if restriction == nil {
+		return nil
+	}
+	value := strings.TrimSpace(getFacetValue(ele))
+	if !isBoundValue(value) {
+		return fmt.Errorf("invalid bound %q", value)
+	}
+	if isMax {
+		restriction.Max, restriction.HasMax, restriction.MaxExclusive = value, true, exclusive
+		return nil
+	}
+	restriction.Min, restriction.HasMin, restriction.MinExclusive = value, true, exclusive
+	return nil
+}
+
+// isBoundValue reports whether the value is the lexical value of a number,
+// date, time or duration, which are the values of the ordered types.
+func isBoundValue(value string) bool {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return true
+	}
+	for _, v := range []encoding.TextUnmarshaler{new(xsd.DateTime), new(xsd.Date), new(xsd.Time), new(xsd.GYearMonth), new(xsd.GYear), new(xsd.GMonthDay), new(xsd.GMonth), new(xsd.GDay), new(xsd.Duration)} {
+		if v.UnmarshalText([]byte(value)) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// onLengthFacet handles the length, minLength and maxLength facets.
+func (opt *Options) onLengthFacet(ele xml.StartElement, isMin, isMax bool) (err error) {
+	restriction := opt.currentRestriction()
+	if restriction == nil {
+		return
+	}
+	var value int
+	if value, err = strconv.Atoi(getFacetValue(ele)); err != nil {
+		return
+	}
+	if isMin {
+		restriction.MinLength, restriction.HasMinLength = value, true
+	}
+	if isMax {
+		restriction.MaxLength, restriction.HasMaxLength = value, true
+	}
+	return
+}
+
+// onPatternFacet handles the pattern facet. XSD patterns are implicitly
+// anchored, and multiple patterns in the same derivation step are combined
+// as alternatives, while the patterns of the base types are kept apart. The
+// patterns are translated to Go regular expressions, the ones which can't be
+// translated are reported as errors when the Validate methods are generated,
+// as they would accept any value, and ignored otherwise.
+func (opt *Options) onPatternFacet(ele xml.StartElement) error {
+	restriction := opt.currentRestriction()
+	if restriction == nil {
+		return nil
+	}
+	pattern, err := compilePattern(getFacetValue(ele))
+	if err != nil {
+		if opt.Validation {
+			return err
+		}
+		return nil
+	}
+	if n := len(restriction.Patterns); opt.patternStep && n > 0 {
+		restriction.Patterns[n-1] = regexp.MustCompile(restriction.Patterns[n-1].String() + "|" + pattern.String())
+		return nil
+	}
+	restriction.Patterns, opt.patternStep = append(restriction.Patterns, pattern), true
+	return nil
+}
@@ -14,5 +14,19 @@ import "encoding/xml"
 // root element of every XML Schema.
 func (opt *Options) OnSchema(ele xml.StartElement, protoTree []interface{}) (err error) {
 	opt.prepareLocalNameNSMap(ele)
+	for _, attr := range ele.Attr {
+		switch {
+		case attr.Name.Space == "xmlns":
+			opt.Schema.namespaces[attr.Name.Local] = attr.Value
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			opt.Schema.namespaces[""] = attr.Value
+		case attr.Name.Local == "targetNamespace":
+			opt.Schema.TargetNamespace = attr.Value
+		case attr.Name.Local == "elementFormDefault":
+			opt.Schema.ElementFormDefault = attr.Value
+		case attr.Name.Local == "attributeFormDefault":
+			opt.Schema.AttributeFormDefault = attr.Value
+		}
+	}
 	return
 }
@@ -0,0 +1,22 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnSimpleContent handles parsing event on the simpleContent start elements.
+// The simpleContent element contains extensions or restrictions on a
+// text-only complex type or on a simple type as content and contains no
+// elements.
+func (opt *Options) OnSimpleContent(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.ComplexType.Peek() != nil {
+		opt.ComplexType.Peek().(*ComplexType).SimpleContent = true
+	}
+	return
+}
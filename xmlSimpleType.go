@@ -32,7 +32,7 @@ func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []interface{})
 // EndSimpleType handles parsing event on the simpleType end elements.
 func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []interface{}) (err error) {
 	if opt.SimpleType.Len() > 0 && opt.Attribute.Len() > 0 {
-		opt.Attribute.Peek().(*Attribute).Type = opt.SimpleType.Pop().(*SimpleType).Base
+		opt.Attribute.Peek().(*Attribute).Type = opt.popSimpleType(&opt.Attribute.Peek().(*Attribute).Restriction)
 		return
 	}
 	if ele.Name.Local == opt.CurrentEle && opt.ComplexType.Len() == 1 {
@@ -8,14 +8,25 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// OnTotalDigits handles parsing event on the totalDigits start elements.
+func (opt *Options) OnTotalDigits(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if restriction := opt.currentRestriction(); restriction != nil {
+		restriction.TotalDigits, err = strconv.Atoi(getFacetValue(ele))
+	}
+	return
+}
 
 // EndTotalDigits handles parsing event on the totalDigits end elements.
 // TotalDigits specifies the exact number of digits allowed. Must be greater
 // than zero.
 func (opt *Options) EndTotalDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && opt.Attribute.Len() == 0 {
+		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.popSimpleType(&opt.Element.Peek().(*Element).Restriction), opt.ProtoTree); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
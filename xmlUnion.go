@@ -21,13 +21,17 @@ func (opt *Options) OnUnion(ele xml.StartElement, protoTree []interface{}) (err
 	if opt.SimpleType.Peek() == nil {
 		return
 	}
-	opt.SimpleType.Peek().(*SimpleType).Union = true
-	opt.SimpleType.Peek().(*SimpleType).MemberTypes = make(map[string]string)
+	simpleType := opt.SimpleType.Peek().(*SimpleType)
+	simpleType.Union = true
+	simpleType.MemberTypes = make(map[string]string)
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "memberTypes" {
-			memberTypes := strings.Split(attr.Value, " ")
-			for _, memberType := range memberTypes {
-				opt.SimpleType.Peek().(*SimpleType).MemberTypes[trimNSPrefix(memberType)], err = opt.GetValueType(memberType, protoTree)
+			for _, memberType := range strings.Fields(attr.Value) {
+				memberName := trimNSPrefix(memberType)
+				if _, ok := simpleType.MemberTypes[memberName]; !ok {
+					simpleType.Members = append(simpleType.Members, memberName)
+				}
+				simpleType.MemberTypes[memberName], err = opt.GetValueType(memberType, protoTree)
 				if err != nil {
 					return
 				}
@@ -0,0 +1,26 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnUnique handles parsing event on the unique start elements. The unique
+// element specifies that the values of the fields must be unique among the
+// elements selected in the scope of the declaring element, if they are
+// present.
+func (opt *Options) OnUnique(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.onIdentityConstraint(ele)
+	return
+}
+
+// EndUnique handles parsing event on the unique end elements.
+func (opt *Options) EndUnique(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
@@ -3,12 +3,21 @@ package xgen
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"math"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	schema "github.com/xuri/xgen/test/go"
+	optcomplex "github.com/xuri/xgen/test/optional/complex"
+	optgeneric "github.com/xuri/xgen/test/optional/generic"
+	optpointer "github.com/xuri/xgen/test/optional/pointer"
+	"github.com/xuri/xgen/test/packages/go/order"
+	validation "github.com/xuri/xgen/test/validation/go"
+	xsdtime "github.com/xuri/xgen/test/xsdtime/go"
+	"github.com/xuri/xgen/xsd"
 )
 
 // TestGeneratedGo runs through test cases to validate Go generated structs. Each test case
@@ -27,6 +36,74 @@ func TestGeneratedGo(t *testing.T) {
 			xmlFileName:     "base64.xml",
 			receivingStruct: &schema.TopLevel{},
 		},
+		{
+			xmlFileName:     "enumeration.xml",
+			receivingStruct: &schema.Wardrobe{},
+		},
+		{
+			xmlFileName:     "any.xml",
+			receivingStruct: &schema.Envelope{},
+		},
+		{
+			xmlFileName:     "substitution.xml",
+			receivingStruct: &schema.Drawing{},
+		},
+		{
+			xmlFileName:     "palette.xml",
+			receivingStruct: &schema.Palette{},
+		},
+		{
+			xmlFileName:     "board.xml",
+			receivingStruct: &schema.Board{},
+		},
+		{
+			xmlFileName:     "derivation.xml",
+			receivingStruct: &schema.NetPrice{},
+		},
+		{
+			xmlFileName:     "fee.xml",
+			receivingStruct: &schema.SmallFee{},
+		},
+		{
+			xmlFileName:     "assertion.xml",
+			receivingStruct: &schema.Library{},
+		},
+		{
+			xmlFileName:     "namespace.xml",
+			receivingStruct: &schema.Inventory{},
+		},
+		{
+			xmlFileName:     "nested.xml",
+			receivingStruct: &schema.PurchaseOrder{},
+		},
+		{
+			xmlFileName:     "list.xml",
+			receivingStruct: &schema.Catalog{},
+		},
+		{
+			xmlFileName:     "packages.xml",
+			receivingStruct: &order.Order{},
+		},
+		{
+			xmlFileName:     "optional.xml",
+			receivingStruct: &optpointer.Profiles{},
+		},
+		{
+			xmlFileName:     "optional.xml",
+			receivingStruct: &optcomplex.Profiles{},
+		},
+		{
+			xmlFileName:     "optional.xml",
+			receivingStruct: &optgeneric.Profiles{},
+		},
+		{
+			xmlFileName:     "schedule.xml",
+			receivingStruct: &xsdtime.Schedule{},
+		},
+		{
+			xmlFileName:     "validation.xml",
+			receivingStruct: &validation.Order{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -52,6 +129,436 @@ func TestGeneratedGo(t *testing.T) {
 	}
 }
 
+func TestGeneratedGoEnumeration(t *testing.T) {
+	assert.Equal(t, []schema.ShirtColor{schema.ShirtColorRed, schema.ShirtColorDarkBlue, schema.ShirtColorLightGreen}, schema.ShirtColorRed.Values())
+	assert.True(t, schema.ShirtColor("dark-blue").IsValid())
+	assert.False(t, schema.ShirtColor("yellow").IsValid())
+	assert.True(t, schema.ShirtSize3.IsValid())
+	assert.False(t, schema.ShirtSize(4).IsValid())
+
+	var wardrobe schema.Wardrobe
+	err := xml.Unmarshal([]byte(`<Wardrobe xmlns="http://example.org/enumeration"><shirt size="2"><color>yellow</color></shirt></Wardrobe>`), &wardrobe)
+	assert.EqualError(t, err, `invalid value "yellow" for ShirtColor`)
+	err = xml.Unmarshal([]byte(`<Wardrobe xmlns="http://example.org/enumeration"><shirt size="4"><color>red</color></shirt></Wardrobe>`), &wardrobe)
+	assert.EqualError(t, err, `invalid value "4" for ShirtSize`)
+	err = xml.Unmarshal([]byte(`<Wardrobe xmlns="http://example.org/enumeration"><shirt size="M"><color>red</color></shirt></Wardrobe>`), &wardrobe)
+	assert.Error(t, err)
+}
+
+func TestGeneratedGoListUnion(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "list.xml"))
+	require.NoError(t, err)
+	var catalog schema.Catalog
+	require.NoError(t, xml.Unmarshal(input, &catalog))
+	require.Len(t, catalog.Garment, 3)
+	assert.Equal(t, &schema.Tags{"cotton", "summer", "sale"}, catalog.Garment[0].TagsAttr)
+	assert.Equal(t, &schema.Sizes{38, 40, 42}, catalog.Garment[0].Sizes)
+	assert.Equal(t, &schema.Weights{0.25, 1.5, 2}, catalog.Garment[0].Weights)
+	assert.Equal(t, &schema.Sizes{}, catalog.Garment[2].Sizes)
+
+	// the members of the unions are tried in declaration order
+	assert.Equal(t, &schema.ClothingSize{Int: &[]int{42}[0]}, catalog.Garment[0].Size)
+	assert.Equal(t, &schema.ClothingSize{SizeName: &[]schema.SizeName{schema.SizeNameMedium}[0]}, catalog.Garment[1].Size)
+	assert.Equal(t, &schema.Threshold{Boolean: &[]bool{true}[0]}, catalog.Garment[0].ThresholdAttr)
+	assert.Equal(t, &schema.Threshold{Decimal: &[]float64{1.5}[0]}, catalog.Garment[1].ThresholdAttr)
+	assert.Equal(t, &schema.Threshold{String: &[]string{"unlimited"}[0]}, catalog.Garment[2].ThresholdAttr)
+	var threshold schema.Threshold
+	require.NoError(t, threshold.UnmarshalText([]byte("1")))
+	assert.Equal(t, schema.Threshold{Boolean: &[]bool{true}[0]}, threshold)
+
+	var size schema.ClothingSize
+	assert.EqualError(t, size.UnmarshalText([]byte("huge")), `xsd: value "huge" does not match any member type of the union`)
+	var sizes schema.Sizes
+	assert.EqualError(t, sizes.UnmarshalText([]byte("1 two 3")), `xsd: invalid integer "two"`)
+
+	// the empty lists and unions are omitted as attributes
+	output, err := xml.Marshal(schema.Garment{TagsAttr: &schema.Tags{}, ThresholdAttr: &schema.Threshold{}})
+	require.NoError(t, err)
+	assert.Equal(t, `<Garment></Garment>`, string(output))
+	_, err = xml.Marshal(schema.Garment{TagsAttr: &schema.Tags{"two words"}})
+	assert.EqualError(t, err, `xsd: list item "two words" is empty or contains whitespace`)
+}
+
+func TestGeneratedGoXSDTimeTypes(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "schedule.xml"))
+	require.NoError(t, err)
+	var schedule xsdtime.Schedule
+	require.NoError(t, xml.Unmarshal(input, &schedule))
+	require.Len(t, schedule.Event, 1)
+	event := schedule.Event[0]
+	assert.True(t, event.Start.Equal(time.Date(2024, 3, 1, 8, 0, 0, 5e8, time.UTC)))
+	assert.True(t, event.Start.HasTimezone)
+	assert.Equal(t, time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC), event.End.Time)
+	assert.False(t, event.End.HasTimezone)
+	assert.Equal(t, time.February, event.Day.Month())
+	assert.Equal(t, 0, event.At.Hour())
+	assert.Equal(t, xsd.Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 75e7}, event.Length)
+	assert.Equal(t, -44, event.Season.Year())
+	assert.Equal(t, 12024, event.Year.Year())
+	assert.Equal(t, 29, event.Anniversary.Day())
+	assert.Equal(t, 1990, event.Birthday.Year())
+	require.Len(t, *event.Holidays, 2)
+	assert.True(t, (*event.Holidays)[1].HasTimezone)
+
+	for _, tc := range []struct {
+		value interface {
+			UnmarshalText([]byte) error
+			String() string
+		}
+		text, canonical string
+	}{
+		{&xsd.DateTime{}, " 2024-01-01T00:00:00.000+00:00 ", "2024-01-01T00:00:00Z"},
+		{&xsd.DateTime{}, "2023-12-31T24:00:00", "2024-01-01T00:00:00"},
+		{&xsd.Time{}, "13:20:00.1230-14:00", "13:20:00.123-14:00"},
+		{&xsd.Time{}, "24:00:00", "00:00:00"},
+		{&xsd.Duration{}, "-P0D", "PT0S"},
+		{&xsd.Duration{}, "PT36H", "PT36H"},
+		{&xsd.GMonthDay{}, "--02-29", "--02-29"},
+	} {
+		require.NoError(t, tc.value.UnmarshalText([]byte(tc.text)), tc.text)
+		assert.Equal(t, tc.canonical, tc.value.String(), tc.text)
+	}
+	for _, tc := range []struct {
+		value interface{ UnmarshalText([]byte) error }
+		text  string
+	}{
+		{&xsd.DateTime{}, "2024-01-01"},
+		{&xsd.DateTime{}, "2024-01-01T00:00"},
+		{&xsd.DateTime{}, "24-01-01T00:00:00"},
+		{&xsd.DateTime{}, "2024-01-01T24:00:01"},
+		{&xsd.Date{}, "2023-02-29"},
+		{&xsd.Date{}, "2024-13-01"},
+		{&xsd.Time{}, "12:00:00+14:30"},
+		{&xsd.Time{}, "12:60:00"},
+		{&xsd.Duration{}, "P"},
+		{&xsd.Duration{}, "P1DT"},
+		{&xsd.Duration{}, "PT1.S"},
+		{&xsd.Duration{}, "P1H"},
+		{&xsd.GYearMonth{}, "2024-1"},
+		{&xsd.GMonth{}, "--13"},
+		{&xsd.GDay{}, "---32"},
+	} {
+		assert.Error(t, tc.value.UnmarshalText([]byte(tc.text)), tc.text)
+	}
+}
+
+func TestGeneratedGoAlternatives(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "assertion.xml"))
+	require.NoError(t, err)
+	var library schema.Library
+	require.NoError(t, xml.Unmarshal(input, &library))
+	require.Len(t, library.Publication, 3)
+	assert.IsType(t, &schema.BookType{}, library.Publication[0].Value)
+	assert.IsType(t, &schema.MagazineType{}, library.Publication[1].Value)
+	assert.IsType(t, &schema.PublicationType{}, library.Publication[2].Value)
+	require.NotNil(t, library.Featured)
+	assert.IsType(t, &schema.BookType{}, library.Featured.Value)
+
+	var featured schema.FeaturedAlternative
+	require.NoError(t, xml.Unmarshal([]byte(`<featured kind="magazine"><title>Wired</title></featured>`), &featured))
+	assert.Equal(t, &schema.PublicationType{KindAttr: &[]string{"magazine"}[0], Title: "Wired"}, featured.Value)
+}
+
+func TestGeneratedGoNamespaces(t *testing.T) {
+	var inventory schema.Inventory
+	require.NoError(t, xml.Unmarshal([]byte(`<inv:Inventory xmlns:inv="http://example.org/inventory"><inv:item id="A1" inv:status="ok"><inv:name>Bolt</inv:name><comment>spare</comment><inv:warehouse>North</inv:warehouse></inv:item></inv:Inventory>`), &inventory))
+	require.Len(t, inventory.Item, 1)
+	require.NotNil(t, inventory.Item[0].StatusAttr)
+	assert.Equal(t, "ok", *inventory.Item[0].StatusAttr)
+	assert.Equal(t, "Bolt", inventory.Item[0].Name)
+	assert.Equal(t, "North", inventory.Item[0].InvWarehouse)
+
+	// the qualified elements and attributes don't match the names in other namespaces
+	inventory = schema.Inventory{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Inventory xmlns="http://example.org/inventory" xmlns:other="http://example.org/other"><item id="A1" other:status="ok"><other:name>Bolt</other:name></item></Inventory>`), &inventory))
+	require.Len(t, inventory.Item, 1)
+	assert.Nil(t, inventory.Item[0].StatusAttr)
+	assert.Empty(t, inventory.Item[0].Name)
+	err := xml.Unmarshal([]byte(`<Inventory xmlns="http://example.org/other"/>`), &inventory)
+	assert.EqualError(t, err, "expected element <Inventory> in name space http://example.org/inventory but have http://example.org/other")
+}
+
+func TestGeneratedGoRecursive(t *testing.T) {
+	var document schema.Document
+	require.NoError(t, xml.Unmarshal([]byte(`<Document><tree><label>root</label><child><label>a</label><child><label>a1</label></child></child><child><label>b</label></child></tree><formula><operator>+</operator><left><value>1</value></left><right><expression><operator>*</operator><left><value>2</value></left><right><value>3</value></right></expression></right></formula></Document>`), &document))
+	require.Len(t, document.Tree.Child, 2)
+	require.Len(t, document.Tree.Child[0].Child, 1)
+	assert.Equal(t, "a1", document.Tree.Child[0].Child[0].Label)
+	require.NotNil(t, document.Formula.Right.Expression)
+	assert.Equal(t, 3, *document.Formula.Right.Expression.Right.Value)
+}
+
+func TestGeneratedGoNestedNames(t *testing.T) {
+	var order schema.Order
+	require.NoError(t, xml.Unmarshal([]byte(`<Order><item><qty>2</qty></item><catalogItem><sku>A1</sku></catalogItem></Order>`), &order))
+	require.Len(t, order.Item, 1)
+	assert.Equal(t, 2, order.Item[0].Qty)
+	assert.Equal(t, "A1", order.CatalogItem.Sku)
+}
+
+func TestGeneratedGoDefaults(t *testing.T) {
+	ticket := schema.NewTicket()
+	require.NotNil(t, ticket.LangAttr)
+	assert.Equal(t, "en", *ticket.LangAttr)
+	require.NotNil(t, ticket.ClassAttr)
+	assert.Equal(t, schema.SeatClassBusiness, *ticket.ClassAttr)
+	require.NotNil(t, ticket.Refundable)
+	assert.False(t, *ticket.Refundable)
+	assert.Equal(t, 1, ticket.Quantity)
+	assert.Equal(t, "EUR", ticket.Currency)
+	assert.Equal(t, schema.SeatClassEconomy, schema.NewSeating().Seat)
+	assert.Equal(t, 1, *schema.NewAuditAttrs().RevisionAttr)
+
+	// the absent fields keep the default values
+	require.NoError(t, xml.Unmarshal([]byte(`<Ticket lang="de"><holder>Ada</holder><quantity>2</quantity></Ticket>`), ticket))
+	assert.Equal(t, "de", *ticket.LangAttr)
+	assert.Equal(t, 2, ticket.Quantity)
+	assert.Equal(t, 9.5, *ticket.Price)
+	assert.Equal(t, "EUR", ticket.Currency)
+}
+
+func TestGeneratedGoOptionality(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "optional.xml"))
+	require.NoError(t, err)
+
+	var pointer optpointer.Profiles
+	require.NoError(t, xml.Unmarshal(input, &pointer))
+	require.Len(t, pointer.Profile, 2)
+	assert.Equal(t, 30, *pointer.Profile[0].Age)
+	assert.Nil(t, pointer.Profile[1].Age)
+	assert.Nil(t, pointer.Profile[1].ActiveAttr)
+	assert.NoError(t, pointer.Validate())
+
+	var complex optcomplex.Profiles
+	require.NoError(t, xml.Unmarshal(input, &complex))
+	require.Len(t, complex.Profile, 2)
+	assert.Equal(t, 30, complex.Profile[0].Age)
+	assert.Zero(t, complex.Profile[1].Age)
+	assert.Nil(t, complex.Profile[1].Address)
+	assert.NoError(t, complex.Validate())
+
+	var generic optgeneric.Profiles
+	require.NoError(t, xml.Unmarshal(input, &generic))
+	require.Len(t, generic.Profile, 2)
+	assert.Equal(t, xsd.Some(30), generic.Profile[0].Age)
+	assert.Equal(t, xsd.Some(true), generic.Profile[0].ActiveAttr)
+	assert.Equal(t, xsd.Some(optgeneric.Code("XY")), generic.Profile[0].Code)
+	assert.False(t, generic.Profile[1].Age.Valid)
+	assert.False(t, generic.Profile[1].ActiveAttr.Valid)
+	assert.NoError(t, generic.Validate())
+
+	// the absent optional values are omitted, the present zero values are not
+	output, err := xml.Marshal(optgeneric.Profile{Name: "Eve", Age: xsd.Some(0), ActiveAttr: xsd.Some(false)})
+	require.NoError(t, err)
+	assert.Equal(t, `<Profile id="" active="false"><name>Eve</name><age>0</age></Profile>`, string(output))
+	output, err = xml.Marshal(optcomplex.Profile{Name: "Eve"})
+	require.NoError(t, err)
+	assert.Equal(t, `<Profile id=""><name>Eve</name></Profile>`, string(output))
+
+	// the defaults and facets of the present values are checked
+	profile := optgeneric.NewProfile()
+	assert.Equal(t, xsd.Some(optgeneric.LevelLow), profile.Level)
+	profile.Code = xsd.Some(optgeneric.Code("X"))
+	assert.EqualError(t, profile.Validate(), "Profile.Code: Code: length 1 is less than 2")
+	profile.Code = xsd.Optional[optgeneric.Code]{}
+	profile.VersionAttr = xsd.Some(2.0)
+	assert.EqualError(t, profile.Validate(), "Profile.VersionAttr: value 2 is not the fixed value 1.5")
+	err = xml.Unmarshal([]byte(`<Profile id="p3" active="yes"><name>Zed</name></Profile>`), profile)
+	assert.EqualError(t, err, `xsd: invalid boolean "yes"`)
+
+	// the absent optional values hold no value of the identity constraints
+	constraint := xsd.IdentityConstraint{Kind: "unique", Name: "age", Selector: "profile", Fields: []string{"age"}}
+	generic.Profile = append(generic.Profile, &optgeneric.Profile{Name: "Eve"})
+	assert.NoError(t, xsd.CheckIdentityConstraints(&generic, constraint))
+	generic.Profile[2].Age = xsd.Some(30)
+	assert.EqualError(t, xsd.CheckIdentityConstraints(&generic, constraint), `unique "age": duplicate value "30"`)
+}
+
+func TestGeneratedGoValidate(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "validation.xml"))
+	require.NoError(t, err)
+
+	unmarshal := func(t *testing.T) *validation.Order {
+		var order validation.Order
+		require.NoError(t, xml.Unmarshal(input, &order))
+		return &order
+	}
+	assert.NoError(t, unmarshal(t).Validate())
+
+	testCases := []struct {
+		name   string
+		modify func(order *validation.Order)
+		err    string
+	}{
+		{
+			name:   "minLength",
+			modify: func(order *validation.Order) { order.Line[0].Sku = "A-1" },
+			err:    "Order.Line: Line.Sku: Sku: length 3 is less than 5",
+		},
+		{
+			name:   "pattern",
+			modify: func(order *validation.Order) { order.Line[1].Sku = "ABCDEF" },
+			err:    `Order.Line: Line.Sku: Sku: value "ABCDEF" does not match pattern ^(?:[A-Z]{2}-\p{Nd}+)$`,
+		},
+		{
+			name:   "maxExclusive",
+			modify: func(order *validation.Order) { order.Line[1].Quantity = 100 },
+			err:    "Order.Line: Line.Quantity: Quantity: value 100 is greater than or equal to 100",
+		},
+		{
+			name: "minInclusive",
+			modify: func(order *validation.Order) {
+				number := validation.Quantity(0)
+				order.Line[0].NumberAttr = &number
+			},
+			err: "Order.Line: Line.NumberAttr: Quantity: value 0 is less than 1",
+		},
+		{
+			name:   "minExclusive",
+			modify: func(order *validation.Order) { order.Line[0].Price.Amount = 0 },
+			err:    "Order.Line: Line.Price: Price.Amount: value 0 is less than or equal to 0",
+		},
+		{
+			name:   "enumeration",
+			modify: func(order *validation.Order) { order.Line[0].Price.CurrencyAttr = "GBP" },
+			err:    "Order.Line: Line.Price: Price.CurrencyAttr: Currency: invalid value GBP",
+		},
+		{
+			name: "optional",
+			modify: func(order *validation.Order) {
+				note := "please deliver before noon"
+				order.Line[0].Note = &note
+			},
+			err: "Order.Line: Line.Note: length 26 is greater than 20",
+		},
+		{
+			name: "patterns",
+			modify: func(order *validation.Order) {
+				label := "x-1"
+				order.Line[0].Label = &label
+			},
+			err: `Order.Line: Line.Label: value "x-1" does not match pattern ^(?:[a-z]+)$`,
+		},
+		{
+			name:   "required",
+			modify: func(order *validation.Order) { order.Line[1].Price = nil },
+			err:    `Order.Line: Line: missing required element "price"`,
+		},
+		{
+			name:   "requiredPlural",
+			modify: func(order *validation.Order) { order.Line = nil },
+			err:    `Order: missing required element "line"`,
+		},
+		{
+			name: "fixed",
+			modify: func(order *validation.Order) {
+				unit := "kg"
+				order.Line[0].UnitAttr = &unit
+			},
+			err: "Order.Line: Line.UnitAttr: value kg is not the fixed value pcs",
+		},
+		{
+			name:   "key",
+			modify: func(order *validation.Order) { order.Line[1].Sku = "AB-123" },
+			err:    `Order: key "lineSku": duplicate value "AB-123"`,
+		},
+		{
+			name: "unique",
+			modify: func(order *validation.Order) {
+				number := validation.Quantity(1)
+				order.Line[1].NumberAttr = &number
+			},
+			err: `Order: unique "lineNumber": duplicate value "1"`,
+		},
+		{
+			name:   "keyref",
+			modify: func(order *validation.Order) { order.Related = append(order.Related, "GH-100") },
+			err:    `Order: keyref "relatedLine": value "GH-100" does not match any value of "lineSku"`,
+		},
+		{
+			name:   "uniqueScope",
+			modify: func(order *validation.Order) { order.Bundle.Line = append(order.Bundle.Line, order.Bundle.Line[0]) },
+			err:    `Order: unique "bundleLine": duplicate value "EF-89"`,
+		},
+		{
+			name: "attributeGroup",
+			modify: func(order *validation.Order) {
+				order.TnsAudit = &validation.Audit{AuthorAttr: &[]string{"a very long name"}[0]}
+			},
+			err: "Order.TnsAudit: Audit.AuthorAttr: length 16 is greater than 10",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			order := unmarshal(t)
+			tc.modify(order)
+			assert.EqualError(t, order.Validate(), tc.err)
+		})
+	}
+
+	// the keyrefs are checked against the constraints of the enclosing and
+	// descendant scopes
+	order := unmarshal(t)
+	lineSku := xsd.IdentityConstraint{Kind: "key", Name: "lineSku", Selector: "tns:line", Fields: []string{"tns:sku"}}
+	bundleSku := xsd.IdentityConstraint{Kind: "key", Name: "bundleSku", Scope: "bundle", Selector: "tns:line", Fields: []string{"tns:sku"}}
+	relatedBundle := xsd.IdentityConstraint{Kind: "keyref", Name: "relatedBundle", Refer: "tns:bundleSku", Selector: "tns:related", Fields: []string{"."}}
+	assert.EqualError(t, xsd.CheckIdentityConstraints(order, bundleSku, relatedBundle), `keyref "relatedBundle": value "CD-4567" does not match any value of "bundleSku"`)
+	order.Related = []validation.Sku{"EF-89"}
+	assert.NoError(t, xsd.CheckIdentityConstraints(order, bundleSku, relatedBundle))
+	bundleLine := xsd.IdentityConstraint{Kind: "keyref", Name: "bundleLine", Refer: "tns:lineSku", Scope: "bundle", Selector: "tns:line", Fields: []string{"tns:sku"}}
+	assert.EqualError(t, xsd.CheckIdentityConstraints(order, lineSku, bundleLine), `keyref "bundleLine": value "EF-89" does not match any value of "lineSku"`)
+	order.Bundle.Line[0].Sku = "AB-123"
+	assert.NoError(t, xsd.CheckIdentityConstraints(order, lineSku, bundleLine))
+	assert.EqualError(t, xsd.CheckIdentityConstraints(order, bundleLine), `keyref "bundleLine": referenced constraint "lineSku" is not declared`)
+
+	batch := &validation.Lines{Line: unmarshal(t).Line}
+	assert.NoError(t, validation.ValidateBatch(batch))
+	batch.Line[1].Price.CurrencyAttr = "EUR"
+	batch.Line[1].Sku = "AB-123"
+	assert.EqualError(t, validation.ValidateBatch(batch), `Batch: unique "batchLine": duplicate value ("AB-123", "EUR")`)
+
+	assert.NoError(t, validation.Code("ABCD").Validate())
+	assert.EqualError(t, validation.Code("ABC").Validate(), "Code: length 3 is less than 4")
+
+	// the escapes and the character class subtraction of XSD are translated,
+	// the patterns of the same derivation step are alternatives
+	for _, tag := range []validation.Tag{"x-1", "_x.y", "#tag"} {
+		assert.NoError(t, tag.Validate(), tag)
+	}
+	for _, tag := range []validation.Tag{"1x", "x$", "#a b", "##", "#é"} {
+		assert.Error(t, tag.Validate(), tag)
+	}
+
+	// the integer bounds are compared exactly
+	assert.NoError(t, validation.Serial(9007199254740993).Validate())
+	assert.EqualError(t, validation.Serial(9007199254740992).Validate(), "Serial: value 9007199254740992 is less than 9007199254740993")
+	assert.EqualError(t, validation.Serial(math.MaxUint64).Validate(), "Serial: value 18446744073709551615 is greater than 18446744073709551614")
+	assert.NoError(t, validation.Offset(-9007199254740992).Validate())
+	assert.Error(t, validation.Offset(-9007199254740993).Validate())
+
+	// the bounds of the date, time and duration types are compared by the
+	// values, the patterns of the other types than string are matched against
+	// the lexical forms
+	assert.NoError(t, validation.Since("2000-01-01").Validate())
+	assert.EqualError(t, validation.Since("1999-12-31").Validate(), "Since: value 1999-12-31 is less than 2000-01-01")
+	assert.EqualError(t, validation.Since("yesterday").Validate(), `Since: xsd: invalid date "yesterday"`)
+	assert.NoError(t, validation.LeadTime("P28D").Validate())
+	assert.EqualError(t, validation.LeadTime("PT721H").Validate(), "LeadTime: value PT721H is greater than P30D")
+	assert.EqualError(t, validation.LeadTime("P1M").Validate(), "LeadTime: xsd: duration P1M is not comparable to P30D")
+	assert.NoError(t, validation.EvenCount(42).Validate())
+	assert.EqualError(t, validation.EvenCount(7).Validate(), `EvenCount: value "7" does not match pattern ^(?:\p{Nd}*[02468])$`)
+	_, cmp, err := xsd.CompareText(xsd.DateTime{Time: time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)}, "2000-01-01T12:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	// the patterns of the base types are checked as well
+	assert.NoError(t, validation.ShortTag("#tag").Validate())
+	err = validation.ShortTag("1x").Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `ShortTag: value "1x" does not match pattern ^(?:[:A-Z_a-z`)
+	assert.EqualError(t, validation.ShortTag("x-123").Validate(), `ShortTag: value "x-123" does not match pattern ^(?:[^\n\r]{1,4})$`)
+}
+
 func TestToTitle(t *testing.T) {
 	test := func(expected, actual string) {
 		assert.Equal(t, expected, ToTitle(actual))
@@ -110,3 +617,40 @@ func TestCodeGeneratorFileWithExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneratedGoSubstitution(t *testing.T) {
+	var drawing schema.Drawing
+	require.NoError(t, xml.Unmarshal([]byte(`<Drawing><title>shapes</title><circle><radius>1</radius></circle><cube><side>2</side></cube></Drawing>`), &drawing))
+	require.Len(t, drawing.Shape, 2)
+	assert.Equal(t, &schema.CircleType{Radius: 1}, drawing.Shape[0].Value)
+	assert.Equal(t, "cube", drawing.Shape[1].XMLName.Local)
+	assert.Equal(t, &schema.SquareType{Side: 2}, drawing.Shape[1].Value)
+
+	err := xml.Unmarshal([]byte(`<Drawing><title>shapes</title><shape/></Drawing>`), &drawing)
+	assert.EqualError(t, err, "unexpected element shape in substitution group of shape")
+
+	// the elements are dispatched to the substitution groups by their names
+	var palette schema.Palette
+	require.NoError(t, xml.Unmarshal([]byte(`<Palette><circle><radius>1</radius></circle><red>r</red></Palette>`), &palette))
+	require.NotNil(t, palette.Elements)
+	assert.Equal(t, &schema.CircleType{Radius: 1}, palette.Elements.Shape.Value)
+	assert.Equal(t, "red", palette.Elements.Color.XMLName.Local)
+	assert.Equal(t, &[]string{"r"}[0], palette.Elements.Color.Value)
+	err = xml.Unmarshal([]byte(`<Palette><green/></Palette>`), &palette)
+	assert.EqualError(t, err, "unexpected element green in Palette")
+
+	// the other elements are matched by the wildcard
+	var board schema.Board
+	require.NoError(t, xml.Unmarshal([]byte(`<Board><name>b</name><cube><side>3</side></cube><note>lax</note></Board>`), &board))
+	require.Len(t, board.Elements.Shape, 1)
+	assert.Equal(t, &schema.SquareType{Side: 3}, board.Elements.Shape[0].Value)
+	require.Len(t, board.Elements.Any, 1)
+	assert.Equal(t, "note", board.Elements.Any[0].XMLName.Local)
+	constraint := xsd.IdentityConstraint{Kind: "key", Name: "lang", Selector: "note", Fields: []string{"@lang"}}
+	assert.EqualError(t, xsd.CheckIdentityConstraints(&board, constraint), `key "lang": missing value of field "@lang"`)
+
+	// the substitution groups are marshalled by values
+	output, err := xml.Marshal(schema.Palette{Elements: &schema.PaletteElements{Color: &schema.ColorSubstitution{XMLName: xml.Name{Local: "blue"}, Value: "b"}}})
+	require.NoError(t, err)
+	assert.Equal(t, `<Palette><blue>b</blue></Palette>`, string(output))
+}
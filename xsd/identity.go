@@ -0,0 +1,504 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xsd provides the runtime support of the Go code generated by xgen,
+// such as the checks of the identity constraints of the XML schema.
+
+package xsd
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IdentityConstraint is a key, keyref or unique constraint of an element
+// declaration. The Kind is one of "key", "keyref" and "unique", and the Refer
+// is the name of the key or unique constraint referenced by the keyref. The
+// Scope is the path of the declaring element relative to the checked value,
+// which is empty for the value itself. The Scope, Selector and Fields are the
+// XPath expressions of the subset defined by the XML schema specification.
+type IdentityConstraint struct {
+	Kind     string
+	Name     string
+	Refer    string
+	Scope    string
+	Selector string
+	Fields   []string
+}
+
+// step is a location step of the XPath expression, which selects the child
+// elements or the attributes with the local name, or all of them by "*".
+type step struct {
+	attr bool
+	name string
+}
+
+// path is a location path of the XPath expression, the descendant reports
+// whether the path starts with the ".//" abbreviation.
+type path struct {
+	descendant bool
+	steps      []step
+}
+
+// CheckIdentityConstraints checks the identity constraints against the
+// unmarshalled document v. It reports the duplicate values of the key and
+// unique constraints, the missing values of the key constraints, and the
+// values of the keyref constraints which don't match any value of the
+// referenced constraint in the same, an enclosing or a descendant scope. The
+// keyref constraints referencing the constraints which aren't declared in
+// these scopes are reported as unresolved. The elements and attributes are
+// matched by the local names in the xml tags of the struct fields.
+func CheckIdentityConstraints(v interface{}, constraints ...IdentityConstraint) error {
+	var scopes []string
+	byScope := make(map[string][]IdentityConstraint)
+	for _, c := range constraints {
+		if _, ok := byScope[c.Scope]; !ok {
+			scopes = append(scopes, c.Scope)
+		}
+		byScope[c.Scope] = append(byScope[c.Scope], c)
+	}
+	root := reflect.ValueOf(v)
+	for _, scope := range scopes {
+		nodes, err := scopeNodes(root, scope)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if err := checkScope(node, byScope[scope]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range constraints {
+		if c.Kind != "keyref" {
+			continue
+		}
+		refer, ok := referencedConstraint(c, constraints)
+		if !ok {
+			return fmt.Errorf("keyref %q: referenced constraint %q is not declared", c.Name, localName(c.Refer))
+		}
+		if refer.Scope == c.Scope {
+			continue
+		}
+		if err := checkKeyref(root, c, refer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referencedConstraint returns the key or unique constraint referenced by
+// the keyref constraint.
+func referencedConstraint(keyref IdentityConstraint, constraints []IdentityConstraint) (IdentityConstraint, bool) {
+	for _, c := range constraints {
+		if c.Kind != "keyref" && c.Name == localName(keyref.Refer) {
+			return c, true
+		}
+	}
+	return IdentityConstraint{}, false
+}
+
+// checkKeyref checks the keyref constraint against the referenced constraint
+// declared in an enclosing or descendant scope. The values of the referenced
+// constraint are collected in each node of the outer one of the scopes, and
+// the values of the keyref are checked in the nodes of its scope under that
+// node.
+func checkKeyref(root reflect.Value, keyref, refer IdentityConstraint) error {
+	outer := refer.Scope
+	if _, ok := relativeScope(keyref.Scope, outer); !ok {
+		if _, ok = relativeScope(outer, keyref.Scope); !ok {
+			return fmt.Errorf("keyref %q: referenced constraint %q is not declared in an enclosing or descendant scope", keyref.Name, refer.Name)
+		}
+		outer = keyref.Scope
+	}
+	referPath, _ := relativeScope(refer.Scope, outer)
+	keyrefPath, _ := relativeScope(keyref.Scope, outer)
+	outerNodes, err := scopeNodes(root, outer)
+	if err != nil {
+		return err
+	}
+	for _, node := range outerNodes {
+		table := make(map[string]bool)
+		referNodes, err := scopeNodes(node, referPath)
+		if err != nil {
+			return err
+		}
+		for _, n := range referNodes {
+			if err := forEachTuple(n, refer, func(tuple string) error {
+				table[tuple] = true
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		keyrefNodes, err := scopeNodes(node, keyrefPath)
+		if err != nil {
+			return err
+		}
+		for _, n := range keyrefNodes {
+			if err := forEachTuple(n, keyref, func(tuple string) error {
+				if !table[tuple] {
+					return fmt.Errorf("keyref %q: value %s does not match any value of %q", keyref.Name, tuple, refer.Name)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// relativeScope returns the path of the scope relative to the enclosing
+// scope, and reports whether the scope is the enclosing scope or inside it.
+func relativeScope(scope, enclosing string) (string, bool) {
+	switch {
+	case enclosing == "":
+		return scope, true
+	case scope == enclosing:
+		return "", true
+	case strings.HasPrefix(scope, enclosing+"/"):
+		return scope[len(enclosing)+1:], true
+	}
+	return "", false
+}
+
+// scopeNodes returns the nodes of the declaring elements selected by the
+// path of the scope from the node, which is the node itself for the empty
+// path.
+func scopeNodes(node reflect.Value, scope string) ([]reflect.Value, error) {
+	if scope == "" {
+		return []reflect.Value{node}, nil
+	}
+	return evaluate(node, scope)
+}
+
+// checkScope checks the identity constraints declared in the element of the
+// node. The keyref constraints are checked after the tables of the key and
+// unique constraints are built, the ones referencing the constraints of
+// other scopes are checked by checkKeyref.
+func checkScope(node reflect.Value, constraints []IdentityConstraint) error {
+	tables := make(map[string]map[string]bool)
+	for _, c := range constraints {
+		if c.Kind == "keyref" {
+			continue
+		}
+		table := make(map[string]bool)
+		if err := forEachTuple(node, c, func(tuple string) error {
+			if table[tuple] {
+				return fmt.Errorf("%s %q: duplicate value %s", c.Kind, c.Name, tuple)
+			}
+			table[tuple] = true
+			return nil
+		}); err != nil {
+			return err
+		}
+		tables[c.Name] = table
+	}
+	for _, c := range constraints {
+		table, ok := tables[localName(c.Refer)]
+		if c.Kind != "keyref" || !ok {
+			continue
+		}
+		if err := forEachTuple(node, c, func(tuple string) error {
+			if !table[tuple] {
+				return fmt.Errorf("keyref %q: value %s does not match any value of %q", c.Name, tuple, localName(c.Refer))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachTuple calls fn with the values of the fields of each element
+// selected by the selector of the constraint. The elements without a value of
+// any field are skipped, except for the key constraints which require them.
+func forEachTuple(node reflect.Value, c IdentityConstraint, fn func(tuple string) error) error {
+	selected, err := evaluate(node, c.Selector)
+	if err != nil {
+		return err
+	}
+	for _, element := range selected {
+		var values []string
+		for _, field := range c.Fields {
+			nodes, err := evaluate(element, field)
+			if err != nil {
+				return err
+			}
+			if len(nodes) > 1 {
+				return fmt.Errorf("%s %q: field %q selects more than one value", c.Kind, c.Name, field)
+			}
+			if len(nodes) == 0 {
+				break
+			}
+			value, err := text(nodes[0])
+			if err != nil {
+				return fmt.Errorf("%s %q: field %q: %w", c.Kind, c.Name, field, err)
+			}
+			values = append(values, fmt.Sprintf("%q", value))
+		}
+		if len(values) < len(c.Fields) {
+			if c.Kind == "key" {
+				return fmt.Errorf("key %q: missing value of field %q", c.Name, c.Fields[len(values)])
+			}
+			continue
+		}
+		tuple := values[0]
+		if len(values) > 1 {
+			tuple = "(" + strings.Join(values, ", ") + ")"
+		}
+		if err := fn(tuple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluate returns the nodes selected by the XPath expression from the node.
+func evaluate(node reflect.Value, expr string) ([]reflect.Value, error) {
+	paths, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	var selected []reflect.Value
+	for _, p := range paths {
+		nodes := []reflect.Value{node}
+		if p.descendant {
+			nodes = descendants(node, nil)
+		}
+		for _, s := range p.steps {
+			var next []reflect.Value
+			for _, n := range nodes {
+				next = append(next, children(n, s)...)
+			}
+			nodes = next
+		}
+		selected = append(selected, nodes...)
+	}
+	return selected, nil
+}
+
+// parse parses the XPath expression of the selector or field, which is a
+// union of the location paths with the child and attribute axes.
+func parse(expr string) (paths []path, err error) {
+	for _, alternative := range strings.Split(expr, "|") {
+		var p path
+		alternative = strings.TrimSpace(alternative)
+		if strings.HasPrefix(alternative, ".//") {
+			p.descendant, alternative = true, alternative[3:]
+		}
+		parts := strings.Split(alternative, "/")
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			var s step
+			switch {
+			case part == ".":
+				continue
+			case strings.HasPrefix(part, "@"):
+				s.attr, part = true, part[1:]
+			case strings.HasPrefix(part, "attribute::"):
+				s.attr, part = true, strings.TrimPrefix(part, "attribute::")
+			case strings.HasPrefix(part, "child::"):
+				part = strings.TrimPrefix(part, "child::")
+			}
+			s.name = localName(strings.TrimSpace(part))
+			if s.name == "" || (s.attr && i != len(parts)-1) || strings.ContainsAny(s.name, "/[]()") {
+				return nil, fmt.Errorf("invalid XPath expression %q", expr)
+			}
+			p.steps = append(p.steps, s)
+		}
+		paths = append(paths, p)
+	}
+	return
+}
+
+// localName returns the local part of the qualified name.
+func localName(name string) string {
+	if i := strings.LastIndexAny(name, ": "); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// descendants appends the node and its descendant elements to the nodes.
+func descendants(node reflect.Value, nodes []reflect.Value) []reflect.Value {
+	nodes = append(nodes, node)
+	for _, child := range children(node, step{name: "*"}) {
+		nodes = descendants(child, nodes)
+	}
+	return nodes
+}
+
+// children returns the child elements or attributes of the node matched by
+// the step, by the names in the xml tags of the struct fields. The fields of
+// the embedded structs are the fields of the node.
+func children(node reflect.Value, s step) (nodes []reflect.Value) {
+	node = indirect(node)
+	if !node.IsValid() || node.Kind() != reflect.Struct {
+		return
+	}
+	t := node.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Name == "XMLName" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		tag := f.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+		flags := strings.Split(tag, ",")
+		name, flags := flags[0], flags[1:]
+		if f.Anonymous && tag == "" {
+			nodes = append(nodes, children(node.Field(i), s)...)
+			continue
+		}
+		if hasFlag(flags, "chardata") || hasFlag(flags, "innerxml") || hasFlag(flags, "comment") || hasFlag(flags, "cdata") {
+			continue
+		}
+		if hasFlag(flags, "attr") != s.attr {
+			continue
+		}
+		if i := strings.LastIndex(name, ">"); i >= 0 {
+			name = name[i+1:]
+		}
+		if name = localName(name); name == "" {
+			name = f.Name
+		}
+		for _, value := range values(node.Field(i)) {
+			items := []reflect.Value{value}
+			if hasFlag(flags, "any") {
+				items = anyValues(value)
+			}
+			for _, item := range items {
+				if hasFlag(flags, "any") {
+					name = anyName(item)
+				}
+				if s.name == "*" || s.name == name {
+					nodes = append(nodes, item)
+				}
+			}
+		}
+	}
+	return
+}
+
+// hasFlag reports whether the options of the xml tag include the flag.
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// anyValues returns the elements or attributes held by the value of a
+// wildcard field. The fields which dispatch the elements to the substitution
+// groups and the wildcard hold them in their own fields.
+func anyValues(value reflect.Value) (nodes []reflect.Value) {
+	if value.Kind() != reflect.Struct || value.Type() == reflect.TypeOf(xml.Attr{}) {
+		return []reflect.Value{value}
+	}
+	if _, ok := value.Type().FieldByName("XMLName"); ok {
+		return []reflect.Value{value}
+	}
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).IsExported() {
+			nodes = append(nodes, values(value.Field(i))...)
+		}
+	}
+	return
+}
+
+// anyName returns the local name of the element or attribute matched by a
+// wildcard field, by the XMLName field of the element.
+func anyName(value reflect.Value) string {
+	if !value.CanInterface() {
+		return ""
+	}
+	if attr, ok := value.Interface().(xml.Attr); ok {
+		return attr.Name.Local
+	}
+	if value.Kind() == reflect.Struct {
+		if name, ok := value.FieldByName("XMLName").Interface().(xml.Name); ok {
+			return name.Local
+		}
+	}
+	return ""
+}
+
+// values returns the values of the field, the slices hold a value for each
+// occurrence of the element, and the nil pointers and the absent optional
+// values hold no value.
+func values(field reflect.Value) (nodes []reflect.Value) {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < field.Len(); i++ {
+			nodes = append(nodes, values(field.Index(i))...)
+		}
+		return
+	}
+	if field = indirect(field); field.IsValid() && field.CanInterface() {
+		if o, ok := field.Interface().(interface{ optional() (interface{}, bool) }); ok {
+			if value, valid := o.optional(); valid {
+				nodes = append(nodes, reflect.ValueOf(value))
+			}
+			return
+		}
+	}
+	if field.IsValid() {
+		nodes = append(nodes, field)
+	}
+	return
+}
+
+// indirect dereferences the pointers and interfaces of the value, it returns
+// the zero value for the nil ones.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// text returns the text of the simple content of the element or attribute.
+func text(value reflect.Value) (string, error) {
+	if !value.CanInterface() {
+		return "", fmt.Errorf("value of unexported field %s", value.Type())
+	}
+	if value.CanAddr() {
+		if m, ok := value.Addr().Interface().(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+			return string(b), err
+		}
+	}
+	if m, ok := value.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	if attr, ok := value.Interface().(xml.Attr); ok {
+		return attr.Value, nil
+	}
+	if value.Kind() == reflect.Struct {
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if hasFlag(strings.Split(t.Field(i).Tag.Get("xml"), ","), "chardata") {
+				return text(indirect(value.Field(i)))
+			}
+		}
+		return "", fmt.Errorf("element %s has no simple content", t.Name())
+	}
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+		return string(value.Bytes()), nil
+	}
+	return fmt.Sprint(value.Interface()), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+package xsd
+
+import (
+	"encoding/xml"
+	"reflect"
+)
+
+// Optional is the value of an optional element or attribute of a simple
+// type. The Valid reports whether the value is present, the absent values are
+// omitted in marshalling.
+type Optional[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Some returns the present optional value v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Valid: true}
+}
+
+// Get returns the value and whether it is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+// optional returns the value and whether it is present, it is implemented by
+// all the instantiations of Optional.
+func (o Optional[T]) optional() (interface{}, bool) {
+	return o.Value, o.Valid
+}
+
+// MarshalXML implements the xml.Marshaler interface and encodes the element
+// with the value if it is present.
+func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !o.Valid {
+		return nil
+	}
+	return e.EncodeElement(o.Value, start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface and decodes the
+// element into the value.
+func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface and omits the
+// attribute if the value is absent.
+func (o Optional[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !o.Valid {
+		return xml.Attr{}, nil
+	}
+	text, err := FormatText(o.Value)
+	return xml.Attr{Name: name, Value: text}, err
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface and parses
+// the attribute value into the value.
+func (o *Optional[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v T
+	if err := ParseText(attr.Value, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// IsZero reports whether v is the zero value of its type, which is the value
+// of an absent optional element or attribute generated without pointers.
+func IsZero(v interface{}) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
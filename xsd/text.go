@@ -0,0 +1,223 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+package xsd
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ParseText parses the lexical form of the simple type value into the value
+// pointed to by v. The values implementing the encoding.TextUnmarshaler
+// interface parse the text by themselves, the whitespace of the other values
+// except the strings is collapsed like the XML schema whitespace facet.
+func ParseText(text string, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("xsd: parse text into non-pointer %T", v)
+	}
+	return parseText(text, value.Elem())
+}
+
+// parseText parses the lexical form of the simple type value into the value.
+func parseText(text string, value reflect.Value) error {
+	if value.CanAddr() && value.Addr().Type().Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+	if value.Kind() != reflect.String {
+		text = strings.TrimSpace(text)
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		switch text {
+		case "true", "1":
+			value.SetBool(true)
+		case "false", "0":
+			value.SetBool(false)
+		default:
+			return fmt.Errorf("xsd: invalid boolean %q", text)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimPrefix(text, "+"), 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("xsd: invalid integer %q", text)
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimPrefix(text, "+"), 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("xsd: invalid unsigned integer %q", text)
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		var err error
+		switch text {
+		case "INF", "+INF":
+			f = math.Inf(1)
+		case "-INF":
+			f = math.Inf(-1)
+		case "NaN":
+			f = math.NaN()
+		default:
+			if strings.ContainsAny(text, "iInN") {
+				return fmt.Errorf("xsd: invalid number %q", text)
+			}
+			if f, err = strconv.ParseFloat(text, value.Type().Bits()); err != nil {
+				return fmt.Errorf("xsd: invalid number %q", text)
+			}
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		return unmarshalList(text, value)
+	default:
+		return fmt.Errorf("xsd: unsupported simple type %s", value.Type())
+	}
+	return nil
+}
+
+// FormatText returns the lexical form of the simple type value v. The values
+// implementing the encoding.TextMarshaler interface format the text by
+// themselves.
+func FormatText(v interface{}) (string, error) {
+	return formatText(reflect.ValueOf(v))
+}
+
+// formatText returns the lexical form of the simple type value.
+func formatText(value reflect.Value) (string, error) {
+	if value.IsValid() && value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		switch {
+		case math.IsInf(f, 1):
+			return "INF", nil
+		case math.IsInf(f, -1):
+			return "-INF", nil
+		case math.IsNaN(f):
+			return "NaN", nil
+		}
+		return strconv.FormatFloat(f, 'g', -1, value.Type().Bits()), nil
+	case reflect.Slice:
+		text, err := marshalList(value)
+		return string(text), err
+	}
+	if !value.IsValid() {
+		return "", fmt.Errorf("xsd: unsupported simple type %v", value)
+	}
+	return "", fmt.Errorf("xsd: unsupported simple type %s", value.Type())
+}
+
+// MarshalList returns the lexical form of the list type value v, which is a
+// slice of the item values separated by spaces.
+func MarshalList(v interface{}) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("xsd: marshal list of non-slice %T", v)
+	}
+	return marshalList(value)
+}
+
+// marshalList returns the lexical form of the list type value.
+func marshalList(value reflect.Value) ([]byte, error) {
+	items := make([]string, value.Len())
+	for i := range items {
+		item, err := formatText(value.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		if item == "" || strings.ContainsAny(item, " \t\r\n") {
+			return nil, fmt.Errorf("xsd: list item %q is empty or contains whitespace", item)
+		}
+		items[i] = item
+	}
+	return []byte(strings.Join(items, " ")), nil
+}
+
+// UnmarshalList splits the lexical form of the list type value by whitespace,
+// and parses the items into the slice pointed to by v.
+func UnmarshalList(text []byte, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("xsd: unmarshal list into non-slice pointer %T", v)
+	}
+	return unmarshalList(string(text), value.Elem())
+}
+
+// unmarshalList parses the items of the list type value into the slice.
+func unmarshalList(text string, value reflect.Value) error {
+	fields := strings.Fields(text)
+	items := reflect.MakeSlice(value.Type(), len(fields), len(fields))
+	for i, field := range fields {
+		if err := parseText(field, items.Index(i)); err != nil {
+			return err
+		}
+	}
+	value.Set(items)
+	return nil
+}
+
+// MarshalUnion returns the lexical form of the union type value, which is held
+// by the first non-nil pointer of the members. It returns an empty text if
+// all the members are nil.
+func MarshalUnion(members ...interface{}) ([]byte, error) {
+	for _, member := range members {
+		value := reflect.ValueOf(member)
+		if value.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("xsd: marshal union member of non-pointer %T", member)
+		}
+		if !value.IsNil() {
+			text, err := formatText(value.Elem())
+			return []byte(text), err
+		}
+	}
+	return nil, nil
+}
+
+// UnmarshalUnion parses the lexical form of the union type value by the
+// members in declaration order. The members are pointers to the pointer
+// fields of the union, and the first one whose type accepts the text is set
+// to the parsed value, the others are set to nil.
+func UnmarshalUnion(text []byte, members ...interface{}) error {
+	fields := make([]reflect.Value, len(members))
+	for i, member := range members {
+		value := reflect.ValueOf(member)
+		if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Ptr {
+			return fmt.Errorf("xsd: unmarshal union member into %T", member)
+		}
+		fields[i] = value.Elem()
+		fields[i].Set(reflect.Zero(fields[i].Type()))
+	}
+	for _, field := range fields {
+		value := reflect.New(field.Type().Elem())
+		if err := parseText(string(text), value.Elem()); err == nil {
+			field.Set(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("xsd: value %q does not match any member type of the union", text)
+}
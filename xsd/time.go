@@ -0,0 +1,485 @@
+// Copyright 2020 - 2025 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+
+package xsd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The date and time types of the XML schema embed the time.Time value, and
+// HasTimezone reports whether the lexical form of the value has a timezone.
+// The location of the time is a fixed zone of the timezone offset, or UTC
+// without a timezone. The fields which the type doesn't have are ignored, and
+// are parsed as January 1 of the year 0, which is a leap year, so that the
+// lexical form "--02-29" of GMonthDay is valid.
+// https://www.w3.org/TR/xmlschema11-2/#dateTime
+
+// DateTime is the dateTime type of the XML schema.
+type DateTime struct {
+	time.Time
+	HasTimezone bool
+}
+
+// Date is the date type of the XML schema.
+type Date struct {
+	time.Time
+	HasTimezone bool
+}
+
+// Time is the time type of the XML schema, which has no date.
+type Time struct {
+	time.Time
+	HasTimezone bool
+}
+
+// GYearMonth is the gYearMonth type of the XML schema.
+type GYearMonth struct {
+	time.Time
+	HasTimezone bool
+}
+
+// GYear is the gYear type of the XML schema.
+type GYear struct {
+	time.Time
+	HasTimezone bool
+}
+
+// GMonthDay is the gMonthDay type of the XML schema.
+type GMonthDay struct {
+	time.Time
+	HasTimezone bool
+}
+
+// GMonth is the gMonth type of the XML schema.
+type GMonth struct {
+	time.Time
+	HasTimezone bool
+}
+
+// GDay is the gDay type of the XML schema.
+type GDay struct {
+	time.Time
+	HasTimezone bool
+}
+
+// Duration is the duration type of the XML schema. The components are kept as
+// they are in the lexical form, so that the months are not normalized to the
+// years or the hours to the days, and the fractional seconds are kept up to
+// nanoseconds.
+type Duration struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+const (
+	yearPattern     = `(-?(?:[1-9]\d{3,}|0\d{3}))`
+	clockPattern    = `(\d{2}):(\d{2}):(\d{2})(\.\d+)?`
+	timezonePattern = `(Z|[+-]\d{2}:\d{2})?`
+)
+
+// calendar describes the lexical form of a date and time type of the XML
+// schema, by the pattern and the components of the date and time in it.
+type calendar struct {
+	name                    string
+	pattern                 *regexp.Regexp
+	year, month, day, clock bool
+}
+
+var (
+	dateTimeCalendar   = calendar{"dateTime", regexp.MustCompile(`^` + yearPattern + `-(\d{2})-(\d{2})T` + clockPattern + timezonePattern + `$`), true, true, true, true}
+	dateCalendar       = calendar{"date", regexp.MustCompile(`^` + yearPattern + `-(\d{2})-(\d{2})` + timezonePattern + `$`), true, true, true, false}
+	timeCalendar       = calendar{"time", regexp.MustCompile(`^` + clockPattern + timezonePattern + `$`), false, false, false, true}
+	gYearMonthCalendar = calendar{"gYearMonth", regexp.MustCompile(`^` + yearPattern + `-(\d{2})` + timezonePattern + `$`), true, true, false, false}
+	gYearCalendar      = calendar{"gYear", regexp.MustCompile(`^` + yearPattern + timezonePattern + `$`), true, false, false, false}
+	gMonthDayCalendar  = calendar{"gMonthDay", regexp.MustCompile(`^--(\d{2})-(\d{2})` + timezonePattern + `$`), false, true, true, false}
+	gMonthCalendar     = calendar{"gMonth", regexp.MustCompile(`^--(\d{2})` + timezonePattern + `$`), false, true, false, false}
+	gDayCalendar       = calendar{"gDay", regexp.MustCompile(`^---(\d{2})` + timezonePattern + `$`), false, false, true, false}
+
+	durationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(\.\d+)?S)?)?$`)
+)
+
+// parse parses the lexical form of the type. The hour 24 is only valid for
+// the first instant of the next day, and the timezone offset is at most 14
+// hours.
+func (c calendar) parse(text string) (time.Time, bool, error) {
+	text = strings.TrimSpace(text)
+	invalid := fmt.Errorf("xsd: invalid %s %q", c.name, text)
+	m := c.pattern.FindStringSubmatch(text)
+	if m == nil {
+		return time.Time{}, false, invalid
+	}
+	m = m[1:]
+	next := func() (n int) {
+		n, _ = strconv.Atoi(m[0])
+		m = m[1:]
+		return
+	}
+	var year, hour, minute, second, nsec int
+	month, day := 1, 1
+	if c.year {
+		var err error
+		if year, err = strconv.Atoi(m[0]); err != nil {
+			return time.Time{}, false, invalid
+		}
+		m = m[1:]
+	}
+	if c.month {
+		month = next()
+	}
+	if c.day {
+		day = next()
+	}
+	if c.clock {
+		hour, minute, second = next(), next(), next()
+		if fraction := m[0]; fraction != "" {
+			digits := (fraction[1:] + "000000000")[:9]
+			nsec, _ = strconv.Atoi(digits)
+			if hour == 24 && strings.Trim(fraction[1:], "0") != "" {
+				return time.Time{}, false, invalid
+			}
+		}
+		m = m[1:]
+	}
+	if month < 1 || month > 12 || day < 1 || day > time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day() ||
+		hour > 24 || (hour == 24 && (minute != 0 || second != 0)) || minute > 59 || second > 59 {
+		return time.Time{}, false, invalid
+	}
+	location, zone := time.UTC, m[0]
+	if zone != "" && zone != "Z" {
+		hours, _ := strconv.Atoi(zone[1:3])
+		minutes, _ := strconv.Atoi(zone[4:6])
+		if minutes > 59 || hours > 14 || (hours == 14 && minutes != 0) {
+			return time.Time{}, false, invalid
+		}
+		offset := (hours*60 + minutes) * 60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		location = time.FixedZone("", offset)
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, nsec, location), zone != "", nil
+}
+
+// format returns the canonical lexical form of the type, which has no
+// trailing zeros of the fractional seconds, and the timezone "Z" for UTC.
+func (c calendar) format(t time.Time, hasTimezone bool) string {
+	var b strings.Builder
+	if c.year {
+		year := t.Year()
+		if year < 0 {
+			b.WriteByte('-')
+			year = -year
+		}
+		fmt.Fprintf(&b, "%04d", year)
+	} else if c.month || c.day {
+		b.WriteString("--")
+	}
+	if c.month {
+		if c.year {
+			b.WriteByte('-')
+		}
+		fmt.Fprintf(&b, "%02d", int(t.Month()))
+	}
+	if c.day {
+		fmt.Fprintf(&b, "-%02d", t.Day())
+	}
+	if c.clock {
+		if c.year {
+			b.WriteByte('T')
+		}
+		fmt.Fprintf(&b, "%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
+		b.WriteString(formatFraction(t.Nanosecond()))
+	}
+	if hasTimezone {
+		_, offset := t.Zone()
+		if offset == 0 {
+			b.WriteByte('Z')
+			return b.String()
+		}
+		sign := '+'
+		if offset < 0 {
+			sign, offset = '-', -offset
+		}
+		fmt.Fprintf(&b, "%c%02d:%02d", sign, offset/3600, offset/60%60)
+	}
+	return b.String()
+}
+
+// formatFraction returns the fractional seconds of the nanoseconds without
+// the trailing zeros, or an empty string for zero.
+func formatFraction(nsec int) string {
+	if nsec == 0 {
+		return ""
+	}
+	return "." + strings.TrimRight(fmt.Sprintf("%09d", nsec), "0")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v DateTime) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *DateTime) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = dateTimeCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v DateTime) String() string {
+	return dateTimeCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Date) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *Date) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = dateCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v Date) String() string {
+	return dateCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Time) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *Time) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = timeCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v Time) String() string {
+	return timeCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GYearMonth) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GYearMonth) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = gYearMonthCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v GYearMonth) String() string {
+	return gYearMonthCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GYear) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GYear) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = gYearCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v GYear) String() string {
+	return gYearCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GMonthDay) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GMonthDay) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = gMonthDayCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v GMonthDay) String() string {
+	return gMonthDayCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GMonth) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GMonth) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = gMonthCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v GMonth) String() string {
+	return gMonthCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v GDay) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *GDay) UnmarshalText(text []byte) (err error) {
+	v.Time, v.HasTimezone, err = gDayCalendar.parse(string(text))
+	return
+}
+
+// String returns the canonical lexical form of the value.
+func (v GDay) String() string {
+	return gDayCalendar.format(v.Time, v.HasTimezone)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Duration) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The
+// lexical form has at least one component, and the time components follow
+// the designator "T".
+func (v *Duration) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return fmt.Errorf("xsd: invalid duration %q", s)
+	}
+	var d Duration
+	d.Negative = m[1] != ""
+	for i, field := range []*int{&d.Years, &d.Months, &d.Days, &d.Hours, &d.Minutes, &d.Seconds} {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return fmt.Errorf("xsd: invalid duration %q", s)
+		}
+		*field = n
+	}
+	if fraction := m[8]; fraction != "" {
+		d.Nanoseconds, _ = strconv.Atoi((fraction[1:] + "000000000")[:9])
+	}
+	*v = d
+	return nil
+}
+
+// String returns the lexical form of the value, which omits the zero
+// components. The zero duration is "PT0S".
+func (v Duration) String() string {
+	var b strings.Builder
+	if v.Negative {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	for _, c := range []struct {
+		n      int
+		suffix byte
+	}{{v.Years, 'Y'}, {v.Months, 'M'}, {v.Days, 'D'}} {
+		if c.n != 0 {
+			fmt.Fprintf(&b, "%d%c", c.n, c.suffix)
+		}
+	}
+	if v.Hours != 0 || v.Minutes != 0 || v.Seconds != 0 || v.Nanoseconds != 0 {
+		b.WriteByte('T')
+		if v.Hours != 0 {
+			fmt.Fprintf(&b, "%dH", v.Hours)
+		}
+		if v.Minutes != 0 {
+			fmt.Fprintf(&b, "%dM", v.Minutes)
+		}
+		if v.Seconds != 0 || v.Nanoseconds != 0 {
+			fmt.Fprintf(&b, "%d%sS", v.Seconds, formatFraction(v.Nanoseconds))
+		}
+	}
+	if b.Len() == len("P") || (v.Negative && b.Len() == len("-P")) {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// calendars are the date and time types, which the lexical forms of the
+// bounds are matched against in CompareText.
+var calendars = []calendar{dateTimeCalendar, dateCalendar, timeCalendar, gYearMonthCalendar, gYearCalendar, gMonthDayCalendar, gMonthCalendar, gDayCalendar}
+
+// durationReferences are the dateTime values which the durations are added
+// to for the comparison, as defined by the XML schema specification.
+// https://www.w3.org/TR/xmlschema11-2/#duration
+var durationReferences = []time.Time{
+	time.Date(1696, 9, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1697, 2, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 3, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, 7, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// CompareText compares the value v of a date, time or duration type with the
+// bound of a range facet, which is the lexical form of a value of the same
+// type. It returns the lexical form of v as FormatText, and -1, 0 or +1 if v
+// is less than, equal to or greater than the bound. The values without a
+// timezone are compared as UTC. The durations are compared by adding them to
+// the reference dateTime values, and an error is returned if the results
+// don't agree.
+func CompareText(v interface{}, bound string) (text string, cmp int, err error) {
+	if text, err = FormatText(v); err != nil {
+		return
+	}
+	for _, c := range calendars {
+		b, _, err := c.parse(bound)
+		if err != nil {
+			continue
+		}
+		t, _, err := c.parse(text)
+		if err != nil {
+			return text, 0, err
+		}
+		return text, t.Compare(b), nil
+	}
+	var d, b Duration
+	if err = b.UnmarshalText([]byte(bound)); err != nil {
+		return text, 0, fmt.Errorf("xsd: invalid bound %q", bound)
+	}
+	if err = d.UnmarshalText([]byte(text)); err != nil {
+		return
+	}
+	for i, reference := range durationReferences {
+		c := d.addTo(reference).Compare(b.addTo(reference))
+		if i > 0 && c != cmp {
+			return text, 0, fmt.Errorf("xsd: duration %s is not comparable to %s", text, bound)
+		}
+		cmp = c
+	}
+	return
+}
+
+// addTo returns the time of the duration added to t.
+func (v Duration) addTo(t time.Time) time.Time {
+	sign := 1
+	if v.Negative {
+		sign = -1
+	}
+	clock := time.Duration(v.Hours)*time.Hour + time.Duration(v.Minutes)*time.Minute + time.Duration(v.Seconds)*time.Second + time.Duration(v.Nanoseconds)
+	return t.AddDate(sign*v.Years, sign*v.Months, sign*v.Days).Add(time.Duration(sign) * clock)
+}